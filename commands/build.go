@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func BuildCommand() components.Command {
+	return components.Command{
+		Name:        "build",
+		Description: "Build-info publish and retrieval tests",
+		Flags:       BuildCommandFlags(),
+		Action: func(c *components.Context) error {
+			buildConfig, err := setBuildConfig(c)
+			if err != nil {
+				return err
+			}
+			return buildCmd(c, buildConfig)
+		},
+	}
+}
+
+func setBuildConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	buildConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.BuildOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("iterations", &buildConfig.Iterations)
+	reader.ReadString("build_name", &buildConfig.BuildName)
+	reader.ReadInt("modules", &buildConfig.Modules)
+	reader.ReadInt("artifacts", &buildConfig.ArtifactsPerModule)
+	reader.ReadInt("dependencies", &buildConfig.DependenciesPerModule)
+	reader.ReadString("url", &buildConfig.Url)
+	reader.ReadString("username", &buildConfig.UserName)
+	reader.ReadString("password", &buildConfig.Password)
+	reader.ReadString("append", &buildConfig.Append)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = buildConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return buildConfig, nil
+}
+
+func BuildCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.BuildOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many build-info documents will be published.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "modules",
+			Description:  "How many modules each of the build-info documents will contain.",
+			DefaultValue: strconv.Itoa(defaults.Modules),
+		},
+		components.StringFlag{
+			Name:         "artifacts",
+			Description:  "How many artifacts each of the modules will contain.",
+			DefaultValue: strconv.Itoa(defaults.ArtifactsPerModule),
+		},
+		components.StringFlag{
+			Name:         "dependencies",
+			Description:  "How many dependencies each of the modules will contain.",
+			DefaultValue: strconv.Itoa(defaults.DependenciesPerModule),
+		},
+		components.StringFlag{
+			Name:         "build_name",
+			Description:  "The name of the published builds, all the builds with this name are deleted at the end of the test.",
+			DefaultValue: defaults.BuildName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func buildCmd(c *components.Context, buildConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'build' command to measure build-info publish and retrieval time of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, buildConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(buildConfig, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureBuildInfoOperationTimes(buildConfig, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(buildConfig.Operation), buildConfig.Append)
+	writeResultsError := benchmarkUtils.WriteItemsOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'build' command.")
+	cleanupErr := benchmarkUtils.DeleteBuild(buildConfig.BuildName, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "items")
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func CopyCommand() components.Command {
+	return moveCopyCommand("copy", "Server-side copy artifacts tests")
+}
+
+func MoveCommand() components.Command {
+	return moveCopyCommand("move", "Server-side move artifacts tests")
+}
+
+// The copy and move commands are identical, except for the operation executed on the uploaded files.
+func moveCopyCommand(operation string, description string) components.Command {
+	return components.Command{
+		Name:        operation,
+		Description: description,
+		Flags:       MoveCopyCommandFlags(operation),
+		Action: func(c *components.Context) error {
+			moveCopyConfig, err := setMoveCopyConfig(c, operation)
+			if err != nil {
+				return err
+			}
+			return moveCopyCmd(c, moveCopyConfig)
+		},
+	}
+}
+
+func setMoveCopyConfig(c *components.Context, operation string) (*benchmarkUtils.BenchmarkConfig, error) {
+	moveCopyConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.Operation(operation))
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("size", &moveCopyConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &moveCopyConfig.Iterations)
+	reader.ReadString("repo_name", &moveCopyConfig.RepositoryName)
+	reader.ReadString("dest_repo_name", &moveCopyConfig.DestinationRepositoryName)
+	reader.ReadString("url", &moveCopyConfig.Url)
+	reader.ReadString("username", &moveCopyConfig.UserName)
+	reader.ReadString("password", &moveCopyConfig.Password)
+	reader.ReadString("append", &moveCopyConfig.Append)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = moveCopyConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return moveCopyConfig, nil
+}
+
+func MoveCopyCommandFlags(operation string) []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.Operation(operation))
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "size",
+			Description:  "Determine the size of the files (in MB) that will be generated for testing the " + operation + " process.",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many files will be created for testing the " + operation + " process.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the files will be uploaded to.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "dest_repo_name",
+			Description:  "The value provided for this flag will determine which repository the files will be copied or moved to.",
+			DefaultValue: defaults.DestinationRepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func moveCopyCmd(c *components.Context, moveCopyConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting '" + moveCopyConfig.Operation + "' command to measure server-side " + moveCopyConfig.Operation + " time of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, moveCopyConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(moveCopyConfig, servicesManager)
+
+	// Creating the source and destination repositories and upload files to the source repository.
+	localRepoError := benchmarkUtils.CreateLocalRepository(moveCopyConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	localRepoError = benchmarkUtils.CreateLocalRepository(moveCopyConfig.DestinationRepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	filesNames, err := benchmarkUtils.GenerateFiles(moveCopyConfig.Iterations, moveCopyConfig.FilesSizesInMb, false)
+	if err != nil {
+		return err
+	}
+	for _, file := range filesNames {
+		_, err := benchmarkUtils.UploadFiles(file, moveCopyConfig.RepositoryName, servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureMoveCopyOperationTimes(moveCopyConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(moveCopyConfig.Operation), moveCopyConfig.Append)
+	writeResultsError := benchmarkUtils.WriteItemsOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished '" + moveCopyConfig.Operation + "' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(moveCopyConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "items")
+}
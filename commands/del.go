@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func DeleteCommand() components.Command {
+	return components.Command{
+		Name:        "del",
+		Description: "Delete artifacts tests",
+		Flags:       DeleteCommandFlags(),
+		Action: func(c *components.Context) error {
+			deleteConfig, err := setDeleteConfig(c)
+			if err != nil {
+				return err
+			}
+			return delCmd(c, deleteConfig)
+		},
+	}
+}
+
+func setDeleteConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	deleteConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.DeleteOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("size", &deleteConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &deleteConfig.Iterations)
+	reader.ReadString("repo_name", &deleteConfig.RepositoryName)
+	reader.ReadString("url", &deleteConfig.Url)
+	reader.ReadString("username", &deleteConfig.UserName)
+	reader.ReadString("password", &deleteConfig.Password)
+	reader.ReadString("append", &deleteConfig.Append)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = deleteConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return deleteConfig, nil
+}
+
+func DeleteCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.DeleteOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "size",
+			Description:  "Determine the size of the files (in MB) that will be generated for testing the delete process.",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many files will be created for testing the delete process.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func delCmd(c *components.Context, deleteConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'del' command to measure delete time from Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, deleteConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(deleteConfig, servicesManager)
+
+	// Creating a repository and upload files that will be used to measure the delete time.
+	localRepoError := benchmarkUtils.CreateLocalRepository(deleteConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	filesNames, err := benchmarkUtils.GenerateFiles(deleteConfig.Iterations, deleteConfig.FilesSizesInMb, false)
+	if err != nil {
+		return err
+	}
+	for _, file := range filesNames {
+		_, err := benchmarkUtils.UploadFiles(file, deleteConfig.RepositoryName, servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureDeleteOperationTimes(deleteConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(deleteConfig.Operation), deleteConfig.Append)
+	writeResultsError := benchmarkUtils.WriteOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'del' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(deleteConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "MB")
+}
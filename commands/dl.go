@@ -12,9 +12,10 @@ func DownloadCommand() components.Command {
 	return components.Command{
 		Name:        "dl",
 		Description: "Download artifacts tests",
-		Flags:       DownloadCommandFlags(),
+		Flags:       benchmarkUtils.WithoutFlagDefaults(DownloadCommandFlags()),
+		EnvVars:     benchmarkUtils.GetFlagEnvVars(DownloadCommandFlags()),
 		Action: func(c *components.Context) error {
-			downloadConfig, err := setDownloadConfig(c)
+			downloadConfig, err := setDownloadConfig(benchmarkUtils.NewEnvConfigValues(c, DownloadCommandFlags()))
 			if err != nil {
 				return err
 			}
@@ -23,18 +24,41 @@ func DownloadCommand() components.Command {
 	}
 }
 
-func setDownloadConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
-	var downloadConfig = new(benchmarkUtils.BenchmarkConfig)
-	downloadConfig.FilesSizesInMb = c.GetStringFlagValue("size")
-	downloadConfig.Iterations = c.GetStringFlagValue("iterations")
-	downloadConfig.RepositoryName = c.GetStringFlagValue("repo_name")
-	downloadConfig.Operation = "download"
-	downloadConfig.Url = c.GetStringFlagValue("url")
-	downloadConfig.UserName = c.GetStringFlagValue("username")
-	downloadConfig.Password = c.GetStringFlagValue("password")
-	downloadConfig.Append = c.GetStringFlagValue("append")
-	downloadConfig.SameFile = c.GetBoolFlagValue("same_file")
-	err := benchmarkUtils.ValidateInput(downloadConfig)
+// Sets the config from the flags, which can also be set by the JFROG_BENCHMARK_<FLAG> environment variables.
+func setDownloadConfig(values *benchmarkUtils.EnvConfigValues) (*benchmarkUtils.BenchmarkConfig, error) {
+	downloadConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.DownloadOperation)
+	reader := benchmarkUtils.NewConfigReader(values)
+	reader.ReadInt("size", &downloadConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &downloadConfig.Iterations)
+	reader.ReadString("repo_name", &downloadConfig.RepositoryName)
+	reader.ReadString("url", &downloadConfig.Url)
+	reader.ReadString("username", &downloadConfig.UserName)
+	reader.ReadString("password", &downloadConfig.Password)
+	reader.ReadString("append", &downloadConfig.Append)
+	reader.ReadBool("same_file", &downloadConfig.SameFile)
+	reader.ReadFloat("rate", &downloadConfig.Rate)
+	reader.ReadInt("concurrency", &downloadConfig.Concurrency)
+	reader.ReadRamp("ramp", &downloadConfig.Ramp)
+	reader.ReadSeconds("step_duration", &downloadConfig.StepDuration)
+	reader.ReadInt("split_count", &downloadConfig.SplitCount)
+	reader.ReadInt64("min_split_size", &downloadConfig.MinSplitSize)
+	reader.ReadBandwidth("bandwidth_limit", &downloadConfig.BandwidthLimit)
+	reader.ReadString("bandwidth_scope", &downloadConfig.BandwidthScope)
+	reader.ReadInt("warmup", &downloadConfig.Warmup)
+	reader.ReadSeconds("server_metrics_interval", &downloadConfig.ServerMetricsInterval)
+	reader.ReadString("metrics_listen", &downloadConfig.MetricsListen)
+	reader.ReadString("push_gateway_url", &downloadConfig.PushGatewayUrl)
+	reader.ReadString("influxdb_url", &downloadConfig.InfluxDbUrl)
+	reader.ReadString("influxdb_token", &downloadConfig.InfluxDbToken)
+	reader.ReadString("push_job", &downloadConfig.PushJob)
+	reader.ReadTags("push_tags", &downloadConfig.PushTags)
+	reader.ReadBool("push_samples", &downloadConfig.PushSamples)
+	values.LogEffectiveConfig()
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = downloadConfig.Validate()
 	if err != nil {
 		return nil, err
 	}
@@ -42,17 +66,18 @@ func setDownloadConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig,
 }
 
 func DownloadCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.DownloadOperation)
 	return []components.Flag{
 		components.StringFlag{
 			Name:         "size",
 			Description:  "The value provided for this flag will determine the size of the files that will be generated for testing the download process.",
-			DefaultValue: "50",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
 			Mandatory:    true,
 		},
 		components.StringFlag{
 			Name:         "iterations",
 			Description:  "This flag specify how many files will be created for testing the download process.",
-			DefaultValue: "30",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
 			Mandatory:    true,
 		},
 		components.BoolFlag{
@@ -60,10 +85,95 @@ func DownloadCommandFlags() []components.Flag {
 			Description:  "If true, the same fill will be uploaded to the repo",
 			DefaultValue: false,
 		},
+		components.StringFlag{
+			Name:         "split_count",
+			Description:  "How many parts each of the large files will be downloaded in concurrently using range requests, 0 disables the split.",
+			DefaultValue: strconv.Itoa(defaults.SplitCount),
+		},
+		components.StringFlag{
+			Name:         "min_split_size",
+			Description:  "The minimal size (in KB) of the files that will be downloaded in parts.",
+			DefaultValue: strconv.FormatInt(defaults.MinSplitSize, 10),
+		},
+		components.StringFlag{
+			Name:         "warmup",
+			Description:  "How many operations are executed before the measured ones without recording them, so the connection setup and the server caches don't skew the results.",
+			DefaultValue: strconv.Itoa(defaults.Warmup),
+		},
+		components.StringFlag{
+			Name:         "server_metrics_interval",
+			Description:  "If set, the storage info and the Open Metrics of Artifactory are collected every this many seconds during the measured operations, and written next to the results.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "metrics_listen",
+			Description:  "If set, the latency, throughput, bytes and errors of the operations are served in the Prometheus format on this address, such as :9100, while the command is running.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "push_gateway_url",
+			Description:  "If set, the summary of the results is pushed to this Prometheus Pushgateway when the command ends, such as http://localhost:9091.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "influxdb_url",
+			Description:  "If set, the summary of the results is pushed in the InfluxDB line protocol to this write endpoint when the command ends, such as http://localhost:8086/write?db=benchmark.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "influxdb_token",
+			Description:  "The token sent to the InfluxDB write endpoint, if it requires one.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "push_job",
+			Description:  "The job the results are pushed as.",
+			DefaultValue: defaults.PushJob,
+		},
+		components.StringFlag{
+			Name:         "push_tags",
+			Description:  "Comma separated name=value tags the pushed results are labeled with, such as env=ci,branch=main.",
+			DefaultValue: "",
+		},
+		components.BoolFlag{
+			Name:         "push_samples",
+			Description:  "If true, the samples of the operations are pushed along with the summary of the results.",
+			DefaultValue: false,
+		},
+		components.StringFlag{
+			Name:         "rate",
+			Description:  "If set, the operations are issued at this fixed rate (operations per second) regardless of the completion of the previous ones.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "concurrency",
+			Description:  "How many operations can be executed at the same time when the rate option is used.",
+			DefaultValue: strconv.Itoa(defaults.Concurrency),
+		},
+		components.StringFlag{
+			Name:         "ramp",
+			Description:  "If set, the operations are executed with each of these comma separated concurrency levels in turn, such as 1,2,4,8, to find where the throughput stops scaling.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "step_duration",
+			Description:  "How many seconds each of the concurrency levels of the ramp option is executed.",
+			DefaultValue: strconv.Itoa(int(defaults.StepDuration.Seconds())),
+		},
+		components.StringFlag{
+			Name:         "bandwidth_limit",
+			Description:  "If set, the transfer of the files is throttled to this bandwidth, such as 10MB/s or 512KB/s.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "bandwidth_scope",
+			Description:  "Whether the bandwidth limit applies to every upload and download stream separately ('worker') or to all of them together ('total').",
+			DefaultValue: defaults.BandwidthScope,
+		},
 		components.StringFlag{
 			Name:         "repo_name",
 			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
-			DefaultValue: "benchmark-dl-tests",
+			DefaultValue: defaults.RepositoryName,
 		},
 		components.StringFlag{
 			Name:         "url",
@@ -95,16 +205,14 @@ func dlCmd(c *components.Context, downloadConfig *benchmarkUtils.BenchmarkConfig
 	if serviceManagerError != nil {
 		return serviceManagerError
 	}
-
-	IterationsInt, _ := strconv.Atoi(downloadConfig.Iterations)
-	FilesSizesInMbInt, _ := strconv.Atoi(downloadConfig.FilesSizesInMb)
+	metadata := benchmarkUtils.NewResultsMetadata(downloadConfig, servicesManager)
 
 	// Creating a repository and upload files that will be used to measure the download time.
 	localRepoError := benchmarkUtils.CreateLocalRepository(downloadConfig.RepositoryName, servicesManager)
 	if localRepoError != nil {
 		return localRepoError
 	}
-	filesNames, err := benchmarkUtils.GenerateFiles(IterationsInt, FilesSizesInMbInt, downloadConfig.SameFile)
+	filesNames, err := benchmarkUtils.GenerateFiles(downloadConfig.Iterations, downloadConfig.FilesSizesInMb, downloadConfig.SameFile)
 	if err != nil {
 		return err
 	}
@@ -114,20 +222,44 @@ func dlCmd(c *components.Context, downloadConfig *benchmarkUtils.BenchmarkConfig
 			return err
 		}
 	}
+	closeMetricsServer, metricsServerError := benchmarkUtils.StartMetricsServerIfNeeded(downloadConfig)
+	if metricsServerError != nil {
+		return metricsServerError
+	}
+	defer closeMetricsServer()
+	warmupError := benchmarkUtils.RunWarmupOperations(downloadConfig, filesNames, servicesManager)
+	if warmupError != nil {
+		return warmupError
+	}
+	metadata.StartMonitors(servicesManager)
+	if len(downloadConfig.Ramp) > 0 {
+		return rampCmd("dl", downloadConfig, metadata, filesNames, servicesManager)
+	}
+	if downloadConfig.Rate > 0 {
+		return rateCmd("dl", downloadConfig, metadata, filesNames, servicesManager)
+	}
 	measureError := benchmarkUtils.MeasureOperationTimes(downloadConfig, filesNames, servicesManager, &benchmarkResults)
 	if measureError != nil {
 		return measureError
 	}
-	path := benchmarkUtils.GetFilePath(downloadConfig.Operation, downloadConfig.Append)
-	writeResultsError := benchmarkUtils.WriteResults(path, benchmarkResults)
+	path := benchmarkUtils.GetFilePath(string(downloadConfig.Operation), downloadConfig.Append)
+	writeResultsError := benchmarkUtils.WriteResults(path, metadata, benchmarkResults)
 	if writeResultsError != nil {
 		return writeResultsError
 	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
 	log.Info("Finished 'dl' command.")
 	cleanupErr := benchmarkUtils.CleanupCliResources(downloadConfig, servicesManager)
 	if cleanupErr != nil {
 		return cleanupErr
 	}
+	pushError := benchmarkUtils.PushResults(metadata)
+	if pushError != nil {
+		return pushError
+	}
 	summriseError := benchmarkUtils.ReadFileAndPrint(path)
 	if summriseError != nil {
 		return summriseError
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func DockerCommand() components.Command {
+	return components.Command{
+		Name:        "docker",
+		Description: "Docker images push and pull tests",
+		Flags:       DockerCommandFlags(),
+		Action: func(c *components.Context) error {
+			dockerConfig, err := setDockerConfig(c)
+			if err != nil {
+				return err
+			}
+			return dockerCmd(c, dockerConfig)
+		},
+	}
+}
+
+func setDockerConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	dockerConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.DockerOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("images", &dockerConfig.Iterations)
+	reader.ReadInt("layers", &dockerConfig.Layers)
+	reader.ReadInt("layer_size", &dockerConfig.FileSizeInKb)
+	reader.ReadString("image_name", &dockerConfig.ImageName)
+	reader.ReadString("repo_name", &dockerConfig.RepositoryName)
+	reader.ReadString("url", &dockerConfig.Url)
+	reader.ReadString("username", &dockerConfig.UserName)
+	reader.ReadString("password", &dockerConfig.Password)
+	reader.ReadString("append", &dockerConfig.Append)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = dockerConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return dockerConfig, nil
+}
+
+func DockerCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.DockerOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "images",
+			Description:  "How many images will be pushed and pulled, they are tagged with the numbers 1 to images.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+		},
+		components.StringFlag{
+			Name:         "layers",
+			Description:  "How many layers each of the images will have.",
+			DefaultValue: strconv.Itoa(defaults.Layers),
+		},
+		components.StringFlag{
+			Name:         "layer_size",
+			Description:  "Determine the size of the layers (in KB) that will be generated for the images.",
+			DefaultValue: strconv.Itoa(defaults.FileSizeInKb),
+		},
+		components.StringFlag{
+			Name:         "image_name",
+			Description:  "The name of the pushed images.",
+			DefaultValue: defaults.ImageName,
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which Docker repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func dockerCmd(c *components.Context, dockerConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'docker' command to measure Docker images push and pull times of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, dockerConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(dockerConfig, servicesManager)
+
+	localRepoError := benchmarkUtils.CreateDockerLocalRepository(dockerConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureDockerOperationTimes(dockerConfig, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(dockerConfig.Operation), dockerConfig.Append)
+	writeResultsError := benchmarkUtils.WriteKBOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'docker' command.")
+	// The images are generated in memory, so only the repository is deleted.
+	cleanupErr := benchmarkUtils.DeleteRepository(dockerConfig.RepositoryName, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "KB")
+}
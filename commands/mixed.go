@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func MixedCommand() components.Command {
+	return components.Command{
+		Name:        "mixed",
+		Description: "Concurrent uploads and downloads tests",
+		Flags:       MixedCommandFlags(),
+		Action: func(c *components.Context) error {
+			mixedConfig, err := setMixedConfig(c)
+			if err != nil {
+				return err
+			}
+			return mixedCmd(c, mixedConfig)
+		},
+	}
+}
+
+func setMixedConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	mixedConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.MixedOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("size", &mixedConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &mixedConfig.Iterations)
+	reader.ReadString("repo_name", &mixedConfig.RepositoryName)
+	reader.ReadString("url", &mixedConfig.Url)
+	reader.ReadString("username", &mixedConfig.UserName)
+	reader.ReadString("password", &mixedConfig.Password)
+	reader.ReadString("append", &mixedConfig.Append)
+	reader.ReadBool("same_file", &mixedConfig.SameFile)
+	reader.ReadInt("read_ratio", &mixedConfig.ReadRatio)
+	reader.ReadInt("concurrency", &mixedConfig.Concurrency)
+	reader.ReadBandwidth("bandwidth_limit", &mixedConfig.BandwidthLimit)
+	reader.ReadString("bandwidth_scope", &mixedConfig.BandwidthScope)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = mixedConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return mixedConfig, nil
+}
+
+func MixedCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.MixedOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "size",
+			Description:  "Determine the size of the files (in MB) that will be generated for testing the uploads and downloads.",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many operations will be executed, the same number of files is generated and uploaded before the test.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "read_ratio",
+			Description:  "The percentage of the operations that will be downloads, the rest of them will be uploads.",
+			DefaultValue: strconv.Itoa(defaults.ReadRatio),
+		},
+		components.StringFlag{
+			Name:         "concurrency",
+			Description:  "How many operations will be executed at the same time.",
+			DefaultValue: strconv.Itoa(defaults.Concurrency),
+		},
+		components.BoolFlag{
+			Name:         "same_file",
+			Description:  "If true, the same fill will be uploaded to the repo",
+			DefaultValue: false,
+		},
+		components.StringFlag{
+			Name:         "bandwidth_limit",
+			Description:  "If set, the transfer of the files is throttled to this bandwidth, such as 10MB/s or 512KB/s.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "bandwidth_scope",
+			Description:  "Whether the bandwidth limit applies to every upload and download stream separately ('worker') or to all of them together ('total').",
+			DefaultValue: defaults.BandwidthScope,
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func mixedCmd(c *components.Context, mixedConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'mixed' command to measure concurrent upload and download times of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, mixedConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(mixedConfig, servicesManager)
+
+	// Creating a repository and upload files that will be used by the downloads.
+	localRepoError := benchmarkUtils.CreateLocalRepository(mixedConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	filesNames, err := benchmarkUtils.GenerateFiles(mixedConfig.Iterations, mixedConfig.FilesSizesInMb, mixedConfig.SameFile)
+	if err != nil {
+		return err
+	}
+	for _, file := range filesNames {
+		_, err := benchmarkUtils.UploadFiles(file, mixedConfig.RepositoryName, servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureMixedOperationTimes(mixedConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(mixedConfig.Operation), mixedConfig.Append)
+	writeResultsError := benchmarkUtils.WriteOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'mixed' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(mixedConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "MB")
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func PropsCommand() components.Command {
+	return components.Command{
+		Name:        "props",
+		Description: "Set, get and delete properties tests",
+		Flags:       PropsCommandFlags(),
+		Action: func(c *components.Context) error {
+			propsConfig, err := setPropsConfig(c)
+			if err != nil {
+				return err
+			}
+			return propsCmd(c, propsConfig)
+		},
+	}
+}
+
+func setPropsConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	propsConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.PropsOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("size", &propsConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &propsConfig.Iterations)
+	reader.ReadString("repo_name", &propsConfig.RepositoryName)
+	reader.ReadString("url", &propsConfig.Url)
+	reader.ReadString("username", &propsConfig.UserName)
+	reader.ReadString("password", &propsConfig.Password)
+	reader.ReadString("append", &propsConfig.Append)
+	reader.ReadInt("props_count", &propsConfig.PropsCount)
+	reader.ReadInt("concurrency", &propsConfig.Concurrency)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = propsConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return propsConfig, nil
+}
+
+func PropsCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.PropsOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "size",
+			Description:  "Determine the size of the files (in MB) that will be generated for testing the properties operations.",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many files will be created for testing the properties operations.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "props_count",
+			Description:  "How many properties will be set on each of the files.",
+			DefaultValue: strconv.Itoa(defaults.PropsCount),
+		},
+		components.StringFlag{
+			Name:         "concurrency",
+			Description:  "How many files will be handled at the same time.",
+			DefaultValue: strconv.Itoa(defaults.Concurrency),
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func propsCmd(c *components.Context, propsConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'props' command to measure properties operations time of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, propsConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(propsConfig, servicesManager)
+
+	// Creating a repository and upload files that their properties will be set.
+	localRepoError := benchmarkUtils.CreateLocalRepository(propsConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	filesNames, err := benchmarkUtils.GenerateFiles(propsConfig.Iterations, propsConfig.FilesSizesInMb, false)
+	if err != nil {
+		return err
+	}
+	for _, file := range filesNames {
+		_, err := benchmarkUtils.UploadFiles(file, propsConfig.RepositoryName, servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasurePropsOperationTimes(propsConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(propsConfig.Operation), propsConfig.Append)
+	writeResultsError := benchmarkUtils.WriteItemsOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'props' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(propsConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "items")
+}
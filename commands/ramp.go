@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Measures the operation of the command on the files with each of the concurrency levels of the ramp of the config.
+func rampCmd(commandName string, rampConfig *benchmarkUtils.BenchmarkConfig, metadata *benchmarkUtils.ResultsMetadata, filesNames []string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	var rampResults []benchmarkUtils.RampStepResult
+	measureError := benchmarkUtils.MeasureRampOperationTimes(rampConfig, filesNames, servicesManager, &rampResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(rampConfig.Operation)+"-ramp", rampConfig.Append)
+	writeResultsError := benchmarkUtils.WriteRampResults(path, metadata, rampResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished '" + commandName + "' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(rampConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	pushError := benchmarkUtils.PushResults(metadata)
+	if pushError != nil {
+		return pushError
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	benchmarkUtils.PrintKneePoint(rampResults)
+	return nil
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func RangeCommand() components.Command {
+	return components.Command{
+		Name:        "range",
+		Description: "Range requests download tests",
+		Flags:       RangeCommandFlags(),
+		Action: func(c *components.Context) error {
+			rangeConfig, err := setRangeConfig(c)
+			if err != nil {
+				return err
+			}
+			return rangeCmd(c, rangeConfig)
+		},
+	}
+}
+
+func setRangeConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	rangeConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.RangeOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("size", &rangeConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &rangeConfig.Iterations)
+	reader.ReadString("repo_name", &rangeConfig.RepositoryName)
+	reader.ReadString("url", &rangeConfig.Url)
+	reader.ReadString("username", &rangeConfig.UserName)
+	reader.ReadString("password", &rangeConfig.Password)
+	reader.ReadString("append", &rangeConfig.Append)
+	reader.ReadInt("range_size", &rangeConfig.RangeSize)
+	reader.ReadString("pattern", &rangeConfig.RangePattern)
+	reader.ReadInt("ranges", &rangeConfig.Repeats)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = rangeConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return rangeConfig, nil
+}
+
+func RangeCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.RangeOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "size",
+			Description:  "Determine the size of the files (in MB) that will be generated and uploaded for the range requests.",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many files will be created for the range requests.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "range_size",
+			Description:  "The size (in KB) of each of the requested ranges.",
+			DefaultValue: strconv.Itoa(defaults.RangeSize),
+		},
+		components.StringFlag{
+			Name:         "ranges",
+			Description:  "How many range requests will be sent for each of the files.",
+			DefaultValue: strconv.Itoa(defaults.Repeats),
+		},
+		components.StringFlag{
+			Name:         "pattern",
+			Description:  "The offsets of the ranges, either sequential from the start of the file or random.",
+			DefaultValue: defaults.RangePattern,
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func rangeCmd(c *components.Context, rangeConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'range' command to measure range requests download time of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, rangeConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(rangeConfig, servicesManager)
+
+	// Creating a repository and upload files that the ranges will be requested from.
+	localRepoError := benchmarkUtils.CreateLocalRepository(rangeConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	filesNames, err := benchmarkUtils.GenerateFiles(rangeConfig.Iterations, rangeConfig.FilesSizesInMb, false)
+	if err != nil {
+		return err
+	}
+	for _, file := range filesNames {
+		_, err := benchmarkUtils.UploadFiles(file, rangeConfig.RepositoryName, servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureRangeOperationTimes(rangeConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(rangeConfig.Operation), rangeConfig.Append)
+	writeResultsError := benchmarkUtils.WriteKBOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'range' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(rangeConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "KB")
+}
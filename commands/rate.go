@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Measures the operation of the command on the files at the fixed rate of the config, instead of one after the other.
+func rateCmd(commandName string, rateConfig *benchmarkUtils.BenchmarkConfig, metadata *benchmarkUtils.ResultsMetadata, filesNames []string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	var benchmarkResults []benchmarkUtils.RateBenchmarkResult
+	measureError := benchmarkUtils.MeasureRateOperationTimes(rateConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(rateConfig.Operation)+"-rate", rateConfig.Append)
+	writeResultsError := benchmarkUtils.WriteRateResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished '" + commandName + "' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(rateConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	pushError := benchmarkUtils.PushResults(metadata)
+	if pushError != nil {
+		return pushError
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintRateOperationStats(benchmarkResults)
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func RunCommand() components.Command {
+	return components.Command{
+		Name:        "run",
+		Description: "Run the benchmark phases described in a YAML or JSON scenario file",
+		Flags:       RunCommandFlags(),
+		Action: func(c *components.Context) error {
+			scenario, err := benchmarkUtils.LoadScenario(c.GetStringFlagValue("scenario"))
+			if err != nil {
+				return err
+			}
+			return runCmd(c, scenario)
+		},
+	}
+}
+
+func RunCommandFlags() []components.Flag {
+	return []components.Flag{
+		components.StringFlag{
+			Name:        "scenario",
+			Description: "The path of the scenario file (.yaml, .yml or .json) describing the server, repository, phases and output of the run.",
+			Mandatory:   true,
+		},
+	}
+}
+
+func runCmd(c *components.Context, scenario *benchmarkUtils.Scenario) error {
+	log.Info("Starting 'run' command to run the phases of scenario [" + scenario.Name + "]...")
+	var scenarioResults []benchmarkUtils.ScenarioResult
+	runConfig := scenario.GetBenchmarkConfig()
+	if err := runConfig.Validate(); err != nil {
+		return err
+	}
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, runConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(runConfig, servicesManager)
+
+	localRepoError := benchmarkUtils.CreateLocalRepository(scenario.Repository, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureScenarioOperationTimes(scenario, servicesManager, &scenarioResults)
+	if measureError != nil {
+		// The repository and the files of the phases that already ran are removed before failing.
+		if cleanupErr := benchmarkUtils.CleanupCliResources(runConfig, servicesManager); cleanupErr != nil {
+			log.Error("Failed to cleanup the CLI created resources - " + cleanupErr.Error())
+		}
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(scenario.Name, scenario.Output.File)
+	writeResultsError := benchmarkUtils.WriteScenarioResults(path, metadata, scenarioResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'run' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(runConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintScenarioOperationStats(scenario, scenarioResults)
+}
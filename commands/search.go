@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func SearchCommand() components.Command {
+	return components.Command{
+		Name:        "search",
+		Description: "AQL and search queries tests",
+		Flags:       SearchCommandFlags(),
+		Action: func(c *components.Context) error {
+			searchConfig, err := setSearchConfig(c)
+			if err != nil {
+				return err
+			}
+			return searchCmd(c, searchConfig)
+		},
+	}
+}
+
+func setSearchConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	searchConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.SearchOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("size", &searchConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &searchConfig.Iterations)
+	reader.ReadString("repo_name", &searchConfig.RepositoryName)
+	reader.ReadString("url", &searchConfig.Url)
+	reader.ReadString("username", &searchConfig.UserName)
+	reader.ReadString("password", &searchConfig.Password)
+	reader.ReadString("append", &searchConfig.Append)
+	reader.ReadString("aql", &searchConfig.AqlQueries)
+	reader.ReadString("patterns", &searchConfig.SearchPatterns)
+	reader.ReadString("props", &searchConfig.SearchProps)
+	reader.ReadInt("repeats", &searchConfig.Repeats)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = searchConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return searchConfig, nil
+}
+
+func SearchCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.SearchOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "size",
+			Description:  "Determine the size of the files (in MB) that will be generated for seeding the repository.",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "iterations",
+			Description:  "This flag specify how many files will be created for seeding the repository.",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
+			Mandatory:    true,
+		},
+		components.StringFlag{
+			Name:         "aql",
+			Description:  "AQL queries separated by '|' that will be measured, {repo} is replaced with the repository name.",
+			DefaultValue: defaults.AqlQueries,
+		},
+		components.StringFlag{
+			Name:         "patterns",
+			Description:  "Search patterns separated by '|' that will be measured, {repo} is replaced with the repository name.",
+			DefaultValue: defaults.SearchPatterns,
+		},
+		components.StringFlag{
+			Name:         "props",
+			Description:  "Properties searches separated by '|' that will be measured on all the files of the repository, each in the key1=value1;key2=value2 format.",
+			DefaultValue: defaults.SearchProps,
+		},
+		components.StringFlag{
+			Name:         "repeats",
+			Description:  "How many times each of the queries will be measured.",
+			DefaultValue: strconv.Itoa(defaults.Repeats),
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func searchCmd(c *components.Context, searchConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'search' command to measure search latency of Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, searchConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(searchConfig, servicesManager)
+
+	// Creating a repository and upload files with properties that will be searched.
+	localRepoError := benchmarkUtils.CreateLocalRepository(searchConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	filesNames, err := benchmarkUtils.GenerateFiles(searchConfig.Iterations, searchConfig.FilesSizesInMb, false)
+	if err != nil {
+		return err
+	}
+	for i, file := range filesNames {
+		_, err := benchmarkUtils.UploadFilesWithProps(file, searchConfig.RepositoryName, benchmarkUtils.GetSeedProps(i+1), servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureSearchOperationTimes(searchConfig, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(searchConfig.Operation), searchConfig.Append)
+	writeResultsError := benchmarkUtils.WriteItemsOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'search' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(searchConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "items")
+}
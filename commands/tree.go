@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"benchmark/lib/benchmarkUtils"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func TreeCommand() components.Command {
+	return components.Command{
+		Name:        "tree",
+		Description: "Recursive upload of a directory tree of many small files tests",
+		Flags:       TreeCommandFlags(),
+		Action: func(c *components.Context) error {
+			treeConfig, err := setTreeConfig(c)
+			if err != nil {
+				return err
+			}
+			return treeCmd(c, treeConfig)
+		},
+	}
+}
+
+func setTreeConfig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
+	treeConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.TreeOperation)
+	reader := benchmarkUtils.NewConfigReader(c)
+	reader.ReadInt("files", &treeConfig.TreeFiles)
+	reader.ReadInt("depth", &treeConfig.TreeDepth)
+	reader.ReadInt("fan_out", &treeConfig.TreeFanOut)
+	reader.ReadInt("file_size", &treeConfig.FileSizeInKb)
+	reader.ReadInt("threads", &treeConfig.Threads)
+	reader.ReadString("repo_name", &treeConfig.RepositoryName)
+	reader.ReadString("url", &treeConfig.Url)
+	reader.ReadString("username", &treeConfig.UserName)
+	reader.ReadString("password", &treeConfig.Password)
+	reader.ReadString("append", &treeConfig.Append)
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = treeConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return treeConfig, nil
+}
+
+func TreeCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.TreeOperation)
+	return []components.Flag{
+		components.StringFlag{
+			Name:         "files",
+			Description:  "How many files will be generated in the directory tree.",
+			DefaultValue: strconv.Itoa(defaults.TreeFiles),
+		},
+		components.StringFlag{
+			Name:         "depth",
+			Description:  "How many levels of directories the tree will have below its root directory.",
+			DefaultValue: strconv.Itoa(defaults.TreeDepth),
+		},
+		components.StringFlag{
+			Name:         "fan_out",
+			Description:  "How many sub-directories each directory of the tree will have, up to its depth.",
+			DefaultValue: strconv.Itoa(defaults.TreeFanOut),
+		},
+		components.StringFlag{
+			Name:         "file_size",
+			Description:  "Determine the size of the files (in KB) that will be generated in the directory tree.",
+			DefaultValue: strconv.Itoa(defaults.FileSizeInKb),
+		},
+		components.StringFlag{
+			Name:         "threads",
+			Description:  "How many files of the tree will be uploaded at the same time.",
+			DefaultValue: strconv.Itoa(defaults.Threads),
+		},
+		components.StringFlag{
+			Name:         "repo_name",
+			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
+			DefaultValue: defaults.RepositoryName,
+		},
+		components.StringFlag{
+			Name:         "url",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] url of Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "username",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] username for Artifactory server",
+		},
+		components.StringFlag{
+			Name:         "password",
+			DefaultValue: "",
+			Description:  "[ONLY ONCE USING CUSTOM SERVER] password for Artifacory server",
+		},
+		components.StringFlag{
+			Name:         "append",
+			DefaultValue: "",
+			Description:  "Append the results to existing results file",
+		},
+	}
+}
+
+func treeCmd(c *components.Context, treeConfig *benchmarkUtils.BenchmarkConfig) error {
+	log.Info("Starting 'tree' command to measure recursive upload time of a directory tree to Artifactory...")
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	servicesManager, serviceManagerError := benchmarkUtils.GetSvcManagerBasedOnAuthLogic(c, treeConfig)
+	if serviceManagerError != nil {
+		return serviceManagerError
+	}
+	metadata := benchmarkUtils.NewResultsMetadata(treeConfig, servicesManager)
+
+	localRepoError := benchmarkUtils.CreateLocalRepository(treeConfig.RepositoryName, servicesManager)
+	if localRepoError != nil {
+		return localRepoError
+	}
+	rootDirectory := filepath.Join(benchmarkUtils.CreateDirectory("/tmp/", "testfiles/"), "tree")
+	filesNames, err := benchmarkUtils.GenerateFileTree(rootDirectory, treeConfig.TreeDepth, treeConfig.TreeFanOut, treeConfig.TreeFiles, treeConfig.FileSizeInKb)
+	if err != nil {
+		return err
+	}
+	metadata.StartMonitors(servicesManager)
+	measureError := benchmarkUtils.MeasureTreeOperationTimes(treeConfig, rootDirectory, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(treeConfig.Operation), treeConfig.Append)
+	writeResultsError := benchmarkUtils.WriteItemsOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'tree' command.")
+	cleanupErr := benchmarkUtils.CleanupCliResources(treeConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "items")
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
@@ -12,9 +13,10 @@ func UploadCommand() components.Command {
 	return components.Command{
 		Name:        "up",
 		Description: "Upload artifacts tests",
-		Flags:       UploadCommandFlags(),
+		Flags:       benchmarkUtils.WithoutFlagDefaults(UploadCommandFlags()),
+		EnvVars:     benchmarkUtils.GetFlagEnvVars(UploadCommandFlags()),
 		Action: func(c *components.Context) error {
-			uploadConfig, err := setUploadConig(c)
+			uploadConfig, err := setUploadConig(benchmarkUtils.NewEnvConfigValues(c, UploadCommandFlags()))
 			if err != nil {
 				return err
 			}
@@ -23,18 +25,43 @@ func UploadCommand() components.Command {
 	}
 }
 
-func setUploadConig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, error) {
-	var uploadConfig = new(benchmarkUtils.BenchmarkConfig)
-	uploadConfig.FilesSizesInMb = c.GetStringFlagValue("size")
-	uploadConfig.Iterations = c.GetStringFlagValue("iterations")
-	uploadConfig.RepositoryName = c.GetStringFlagValue("repo_name")
-	uploadConfig.Operation = "upload"
-	uploadConfig.Url = c.GetStringFlagValue("url")
-	uploadConfig.UserName = c.GetStringFlagValue("username")
-	uploadConfig.Password = c.GetStringFlagValue("password")
-	uploadConfig.Append = c.GetStringFlagValue("append")
-	uploadConfig.SameFile = c.GetBoolFlagValue("same_file")
-	err := benchmarkUtils.ValidateInput(uploadConfig)
+// Sets the config from the flags, which can also be set by the JFROG_BENCHMARK_<FLAG> environment variables.
+func setUploadConig(values *benchmarkUtils.EnvConfigValues) (*benchmarkUtils.BenchmarkConfig, error) {
+	uploadConfig := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.UploadOperation)
+	reader := benchmarkUtils.NewConfigReader(values)
+	reader.ReadInt("size", &uploadConfig.FilesSizesInMb)
+	reader.ReadInt("iterations", &uploadConfig.Iterations)
+	reader.ReadString("repo_name", &uploadConfig.RepositoryName)
+	reader.ReadString("url", &uploadConfig.Url)
+	reader.ReadString("username", &uploadConfig.UserName)
+	reader.ReadString("password", &uploadConfig.Password)
+	reader.ReadString("append", &uploadConfig.Append)
+	reader.ReadBool("same_file", &uploadConfig.SameFile)
+	reader.ReadFloat("rate", &uploadConfig.Rate)
+	reader.ReadInt("concurrency", &uploadConfig.Concurrency)
+	reader.ReadRamp("ramp", &uploadConfig.Ramp)
+	reader.ReadSeconds("step_duration", &uploadConfig.StepDuration)
+	reader.ReadBool("explode", &uploadConfig.Explode)
+	if uploadConfig.Explode {
+		uploadConfig.Operation = benchmarkUtils.UploadExplodeOperation
+	}
+	reader.ReadBandwidth("bandwidth_limit", &uploadConfig.BandwidthLimit)
+	reader.ReadString("bandwidth_scope", &uploadConfig.BandwidthScope)
+	reader.ReadInt("warmup", &uploadConfig.Warmup)
+	reader.ReadSeconds("server_metrics_interval", &uploadConfig.ServerMetricsInterval)
+	reader.ReadString("metrics_listen", &uploadConfig.MetricsListen)
+	reader.ReadString("push_gateway_url", &uploadConfig.PushGatewayUrl)
+	reader.ReadString("influxdb_url", &uploadConfig.InfluxDbUrl)
+	reader.ReadString("influxdb_token", &uploadConfig.InfluxDbToken)
+	reader.ReadString("push_job", &uploadConfig.PushJob)
+	reader.ReadTags("push_tags", &uploadConfig.PushTags)
+	reader.ReadBool("push_samples", &uploadConfig.PushSamples)
+	values.LogEffectiveConfig()
+	err := reader.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = uploadConfig.Validate()
 	if err != nil {
 		return nil, err
 	}
@@ -42,17 +69,18 @@ func setUploadConig(c *components.Context) (*benchmarkUtils.BenchmarkConfig, err
 }
 
 func UploadCommandFlags() []components.Flag {
+	defaults := benchmarkUtils.NewBenchmarkConfig(benchmarkUtils.UploadOperation)
 	return []components.Flag{
 		components.StringFlag{
 			Name:         "size",
 			Description:  "Determine the size of the files (in MB) that will be generated for testing the upload process.",
-			DefaultValue: "50",
+			DefaultValue: strconv.Itoa(defaults.FilesSizesInMb),
 			Mandatory:    true,
 		},
 		components.StringFlag{
 			Name:         "iterations",
 			Description:  "This flag specify how many files will be created for testing the upload process.",
-			DefaultValue: "30",
+			DefaultValue: strconv.Itoa(defaults.Iterations),
 			Mandatory:    true,
 		},
 		components.BoolFlag{
@@ -60,10 +88,90 @@ func UploadCommandFlags() []components.Flag {
 			Description:  "If true, the same fill will be uploaded to the repo",
 			DefaultValue: false,
 		},
+		components.BoolFlag{
+			Name:         "explode",
+			Description:  "If true, the files are uploaded one by one and then as a single zip archive exploded by Artifactory, and both are compared",
+			DefaultValue: false,
+		},
+		components.StringFlag{
+			Name:         "warmup",
+			Description:  "How many operations are executed before the measured ones without recording them, so the connection setup and the server caches don't skew the results.",
+			DefaultValue: strconv.Itoa(defaults.Warmup),
+		},
+		components.StringFlag{
+			Name:         "server_metrics_interval",
+			Description:  "If set, the storage info and the Open Metrics of Artifactory are collected every this many seconds during the measured operations, and written next to the results.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "metrics_listen",
+			Description:  "If set, the latency, throughput, bytes and errors of the operations are served in the Prometheus format on this address, such as :9100, while the command is running.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "push_gateway_url",
+			Description:  "If set, the summary of the results is pushed to this Prometheus Pushgateway when the command ends, such as http://localhost:9091.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "influxdb_url",
+			Description:  "If set, the summary of the results is pushed in the InfluxDB line protocol to this write endpoint when the command ends, such as http://localhost:8086/write?db=benchmark.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "influxdb_token",
+			Description:  "The token sent to the InfluxDB write endpoint, if it requires one.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "push_job",
+			Description:  "The job the results are pushed as.",
+			DefaultValue: defaults.PushJob,
+		},
+		components.StringFlag{
+			Name:         "push_tags",
+			Description:  "Comma separated name=value tags the pushed results are labeled with, such as env=ci,branch=main.",
+			DefaultValue: "",
+		},
+		components.BoolFlag{
+			Name:         "push_samples",
+			Description:  "If true, the samples of the operations are pushed along with the summary of the results.",
+			DefaultValue: false,
+		},
+		components.StringFlag{
+			Name:         "rate",
+			Description:  "If set, the operations are issued at this fixed rate (operations per second) regardless of the completion of the previous ones.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "concurrency",
+			Description:  "How many operations can be executed at the same time when the rate option is used.",
+			DefaultValue: strconv.Itoa(defaults.Concurrency),
+		},
+		components.StringFlag{
+			Name:         "ramp",
+			Description:  "If set, the operations are executed with each of these comma separated concurrency levels in turn, such as 1,2,4,8, to find where the throughput stops scaling.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "step_duration",
+			Description:  "How many seconds each of the concurrency levels of the ramp option is executed.",
+			DefaultValue: strconv.Itoa(int(defaults.StepDuration.Seconds())),
+		},
+		components.StringFlag{
+			Name:         "bandwidth_limit",
+			Description:  "If set, the transfer of the files is throttled to this bandwidth, such as 10MB/s or 512KB/s.",
+			DefaultValue: "",
+		},
+		components.StringFlag{
+			Name:         "bandwidth_scope",
+			Description:  "Whether the bandwidth limit applies to every upload and download stream separately ('worker') or to all of them together ('total').",
+			DefaultValue: defaults.BandwidthScope,
+		},
 		components.StringFlag{
 			Name:         "repo_name",
 			Description:  "The value provided for this flag will determine which repository the tests will be executed on.",
-			DefaultValue: "benchmark-up-tests",
+			DefaultValue: defaults.RepositoryName,
 			Mandatory:    true,
 		},
 		components.StringFlag{
@@ -96,35 +204,92 @@ func upCmd(c *components.Context, uploadConfig *benchmarkUtils.BenchmarkConfig)
 	if serviceManagerError != nil {
 		return serviceManagerError
 	}
-	IterationsInt, _ := strconv.Atoi(uploadConfig.Iterations)
-	FilesSizesInMbInt, _ := strconv.Atoi(uploadConfig.FilesSizesInMb)
+	metadata := benchmarkUtils.NewResultsMetadata(uploadConfig, servicesManager)
 
 	localRepoError := benchmarkUtils.CreateLocalRepository(uploadConfig.RepositoryName, servicesManager)
 	if localRepoError != nil {
 		return localRepoError
 	}
-	filesNames, err := benchmarkUtils.GenerateFiles(IterationsInt, FilesSizesInMbInt, uploadConfig.SameFile)
+	filesNames, err := benchmarkUtils.GenerateFiles(uploadConfig.Iterations, uploadConfig.FilesSizesInMb, uploadConfig.SameFile)
 	if err != nil {
 		return err
 	}
+	closeMetricsServer, metricsServerError := benchmarkUtils.StartMetricsServerIfNeeded(uploadConfig)
+	if metricsServerError != nil {
+		return metricsServerError
+	}
+	defer closeMetricsServer()
+	warmupError := benchmarkUtils.RunWarmupOperations(uploadConfig, filesNames, servicesManager)
+	if warmupError != nil {
+		return warmupError
+	}
+	metadata.StartMonitors(servicesManager)
+	if uploadConfig.Explode {
+		return upExplodeCmd(uploadConfig, metadata, filesNames, servicesManager)
+	}
+	if len(uploadConfig.Ramp) > 0 {
+		return rampCmd("up", uploadConfig, metadata, filesNames, servicesManager)
+	}
+	if uploadConfig.Rate > 0 {
+		return rateCmd("up", uploadConfig, metadata, filesNames, servicesManager)
+	}
 	measureError := benchmarkUtils.MeasureOperationTimes(uploadConfig, filesNames, servicesManager, &benchmarkResults)
 	if measureError != nil {
 		return measureError
 	}
-	path := benchmarkUtils.GetFilePath(uploadConfig.Operation, uploadConfig.Append)
+	path := benchmarkUtils.GetFilePath(string(uploadConfig.Operation), uploadConfig.Append)
 
-	writeResultsError := benchmarkUtils.WriteResults(path, benchmarkResults)
+	writeResultsError := benchmarkUtils.WriteResults(path, metadata, benchmarkResults)
 	if writeResultsError != nil {
 		return writeResultsError
 	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
 	log.Info("Finished 'up' command")
 	cleanupErr := benchmarkUtils.CleanupCliResources(uploadConfig, servicesManager)
 	if cleanupErr != nil {
 		return cleanupErr
 	}
+	pushError := benchmarkUtils.PushResults(metadata)
+	if pushError != nil {
+		return pushError
+	}
 	summriseError := benchmarkUtils.ReadFileAndPrint(path)
 	if summriseError != nil {
 		return summriseError
 	}
 	return nil
 }
+
+func upExplodeCmd(uploadConfig *benchmarkUtils.BenchmarkConfig, metadata *benchmarkUtils.ResultsMetadata, filesNames []string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	var benchmarkResults []benchmarkUtils.BenchmarkResult
+	measureError := benchmarkUtils.MeasureExplodeOperationTimes(uploadConfig, filesNames, servicesManager, &benchmarkResults)
+	if measureError != nil {
+		return measureError
+	}
+	path := benchmarkUtils.GetFilePath(string(uploadConfig.Operation), uploadConfig.Append)
+	writeResultsError := benchmarkUtils.WriteOperationResults(path, metadata, benchmarkResults)
+	if writeResultsError != nil {
+		return writeResultsError
+	}
+	writeMetadataError := benchmarkUtils.WriteResultsMetadata(path, metadata)
+	if writeMetadataError != nil {
+		return writeMetadataError
+	}
+	log.Info("Finished 'up' command")
+	cleanupErr := benchmarkUtils.CleanupCliResources(uploadConfig, servicesManager)
+	if cleanupErr != nil {
+		return cleanupErr
+	}
+	pushError := benchmarkUtils.PushResults(metadata)
+	if pushError != nil {
+		return pushError
+	}
+	summriseError := benchmarkUtils.ReadFileAndPrint(path)
+	if summriseError != nil {
+		return summriseError
+	}
+	return benchmarkUtils.PrintOperationStats(benchmarkResults, "MB")
+}
@@ -3,7 +3,9 @@ module benchmark
 go 1.14
 
 require (
+	github.com/jfrog/build-info-go v1.4.0
 	github.com/jfrog/jfrog-cli-core/v2 v2.19.1
 	github.com/jfrog/jfrog-client-go v1.18.1
 	github.com/stretchr/testify v1.8.0
+	gopkg.in/yaml.v3 v3.0.1
 )
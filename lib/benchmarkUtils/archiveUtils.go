@@ -0,0 +1,143 @@
+package benchmarkUtils
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The folder inside the repository the files are uploaded to one by one, for comparing with the exploded archive.
+	individualUploadFolder = "individual"
+	// The folder inside the repository the archive is uploaded to and exploded in.
+	explodeUploadFolder = "exploded"
+	// How long to wait for the entries of an exploded archive to be visible in the repository.
+	explodeTimeout = 10 * time.Minute
+	// How long to wait between the searches for the entries of an exploded archive.
+	explodePollingInterval = 500 * time.Millisecond
+)
+
+// Bundles the files into a zip archive with the given name, the entries are stored without compression since the
+// generated files are random and can't be compressed anyway.
+func CreateZipArchive(archiveName string, fileNames []string) error {
+	archive, err := os.Create(archiveName)
+	if err != nil {
+		return errors.New("Failed to create archive [" + archiveName + "] - " + err.Error())
+	}
+	defer archive.Close()
+	writer := zip.NewWriter(archive)
+	for _, fileName := range fileNames {
+		err = addFileToZip(writer, fileName)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func addFileToZip(writer *zip.Writer, fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	entry, err := writer.CreateHeader(&zip.FileHeader{Name: filepath.Base(fileName), Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// Uploads the archive to the given folder of the repository and lets Artifactory explode it there. The returned duration
+// is the time from the start of the upload until all the expected entries are visible in the folder.
+func UploadArchiveAndExplode(archiveName string, repositoryName string, folderName string, expectedEntries int,
+	servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	up := services.NewUploadParams()
+	up.CommonParams = &utils.CommonParams{Pattern: archiveName, Recursive: false, Target: repositoryName + "/" + folderName + "/"}
+	up.Flat = true
+	up.ExplodeArchive = true
+	start := time.Now()
+	totalSucceeded, _, err := servicesManager.UploadFiles(up)
+	if err != nil {
+		return 0, err
+	}
+	if totalSucceeded == 0 {
+		return 0, errors.New("Failed to upload archive [" + archiveName + "] to Artifactory")
+	}
+	err = WaitForFolderEntries(repositoryName, folderName, expectedEntries, explodeTimeout, servicesManager)
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// Searches the files directly under the folder of the repository until there are at least the expected number of them,
+// or until the timeout expires.
+func WaitForFolderEntries(repositoryName string, folderName string, expectedEntries int, timeout time.Duration,
+	servicesManager artifactory.ArtifactoryServicesManager) error {
+	query := fmt.Sprintf(`items.find({"repo":"%s","path":"%s","name":{"$match":"*"}})`, repositoryName, folderName)
+	deadline := time.Now().Add(timeout)
+	for {
+		_, found, err := SearchAql(query, servicesManager)
+		if err != nil {
+			return err
+		}
+		if found >= expectedEntries {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Only %d of the %d entries of the archive are visible in [%s/%s] after %s", found, expectedEntries,
+				repositoryName, folderName, timeout)
+		}
+		time.Sleep(explodePollingInterval)
+	}
+}
+
+// Uploads the provided files one by one to a folder of the repository and measures the total time, then bundles them
+// into a zip archive, uploads it with explode to another folder and measures the time until all of its entries are
+// visible. The time of creating the archive locally isn't measured.
+func MeasureExplodeOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	totalMB := st.FilesSizesInMb * len(fileNames)
+	totalBytes := int64(totalMB) * 1024 * 1024
+
+	log.Info("Uploading the files one by one to [" + individualUploadFolder + "] folder")
+	start := time.Now()
+	for _, file := range fileNames {
+		_, err := UploadFilesWithoutChecksumDeploy(file, st.RepositoryName+"/"+individualUploadFolder+"/", servicesManager)
+		if err != nil {
+			return err
+		}
+	}
+	individualDuration := time.Since(start)
+	observeOperation("upload-files", MeasurePhase, totalBytes, individualDuration, nil)
+	*benchmarkResults = append(*benchmarkResults, *NewMBBenchmarkResult("upload-files", st.RepositoryName+"/"+individualUploadFolder+"/",
+		totalMB, individualDuration))
+
+	archiveName := filepath.Join(filepath.Dir(fileNames[0]), "benchmark-archive.zip")
+	log.Info("Bundling the files into [" + archiveName + "]")
+	err := CreateZipArchive(archiveName, fileNames)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archiveName)
+	log.Info("Uploading [" + archiveName + "] with explode to [" + explodeUploadFolder + "] folder")
+	duration, err := UploadArchiveAndExplode(archiveName, st.RepositoryName, explodeUploadFolder, len(fileNames), servicesManager)
+	observeOperation("upload-explode", MeasurePhase, totalBytes, duration, err)
+	if err != nil {
+		return err
+	}
+	*benchmarkResults = append(*benchmarkResults, *NewMBBenchmarkResult("upload-explode", st.RepositoryName+"/"+explodeUploadFolder+"/",
+		totalMB, duration))
+	return nil
+}
@@ -0,0 +1,72 @@
+package benchmarkUtils
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateZipArchive(t *testing.T) {
+	localDir, fileNames := createLocalTestFiles(t, 3)
+	defer os.RemoveAll(localDir)
+
+	archiveName := filepath.Join(localDir, "archive.zip")
+	assert.NoError(t, CreateZipArchive(archiveName, fileNames))
+	archive, err := zip.OpenReader(archiveName)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer archive.Close()
+	if assert.Len(t, archive.File, 3) {
+		assert.Equal(t, "File1.txt", archive.File[0].Name)
+		assert.Equal(t, zip.Store, archive.File[0].Method)
+		reader, err := archive.File[0].Open()
+		assert.NoError(t, err)
+		data, _ := ioutil.ReadAll(reader)
+		reader.Close()
+		expected, _ := ioutil.ReadFile(fileNames[0])
+		assert.Equal(t, expected, data)
+	}
+}
+
+func TestWaitForFolderEntries(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-up-tests/exploded/File1.txt": []byte("first artifact"),
+	})
+	defer fake.Close()
+
+	assert.NoError(t, WaitForFolderEntries("benchmark-up-tests", "exploded", 1, time.Second, servicesManager))
+	assert.Error(t, WaitForFolderEntries("benchmark-up-tests", "exploded", 2, 0, servicesManager),
+		"Expected an error when not all the entries are visible before the timeout")
+}
+
+func TestMeasureExplodeOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 3)
+	defer os.RemoveAll(localDir)
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-up-tests", FilesSizesInMb: 1, Explode: true}
+	assert.NoError(t, MeasureExplodeOperationTimes(config, fileNames, servicesManager, &results))
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "upload-files", results[0].Operation)
+		assert.Equal(t, "benchmark-up-tests/individual/", results[0].FileName)
+		assert.Equal(t, "3", results[0].Size)
+		assert.Equal(t, "upload-explode", results[1].Operation)
+		assert.Equal(t, "benchmark-up-tests/exploded/", results[1].FileName)
+		assert.Equal(t, "3", results[1].Size)
+	}
+	for _, name := range []string{"File1.txt", "File2.txt", "File3.txt"} {
+		assert.Contains(t, fake.artifacts, "benchmark-up-tests/individual/"+name)
+		assert.Contains(t, fake.artifacts, "benchmark-up-tests/exploded/"+name)
+	}
+	assert.NotContains(t, fake.artifacts, "benchmark-up-tests/exploded/benchmark-archive.zip")
+	_, err := os.Stat(filepath.Join(localDir, "benchmark-archive.zip"))
+	assert.True(t, os.IsNotExist(err), "Expected the archive to be removed after the upload")
+}
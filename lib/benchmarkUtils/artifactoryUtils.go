@@ -1,8 +1,13 @@
 package benchmarkUtils
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"math"
 	"net/http"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -15,12 +20,20 @@ import (
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-// Returns the Artifactory Details of the provided server-id, or the default one.
-func getRtDetails(c *components.Context) (*config.ServerDetails, error) {
-	details, err := commands.GetConfig(c.GetStringFlagValue("server-id"), false)
+// The local directory the downloaded files are written to, it is removed as part of the cleanup.
+const DownloadDirectory = "/tmp/testdownloads/"
+
+// Returns the Artifactory Details of the server ID of the config or the provided server-id, or the default one.
+func getRtDetails(c *components.Context, cliConfig *BenchmarkConfig) (*config.ServerDetails, error) {
+	serverId := cliConfig.ServerId
+	if serverId == "" {
+		serverId = c.GetStringFlagValue("server-id")
+	}
+	details, err := commands.GetConfig(serverId, false)
 	if err != nil {
 		return nil, err
 	}
@@ -102,9 +115,9 @@ func GetSvcManagerBasedOnAuthLogic(c *components.Context, cliConfig *BenchmarkCo
 		if serviceMngrErr != nil {
 			return nil, serviceMngrErr
 		}
-		return serviceManger, nil
+		return withBandwidthLimitIfNeeded(cliConfig, serviceManger)
 	} else {
-		confDetails, err := getRtDetails(c)
+		confDetails, err := getRtDetails(c, cliConfig)
 		if err != nil {
 			log.Error("Failed to get server details using default server-id")
 			return nil, err
@@ -113,7 +126,7 @@ func GetSvcManagerBasedOnAuthLogic(c *components.Context, cliConfig *BenchmarkCo
 		if serviceMngrErr != nil {
 			return nil, serviceMngrErr
 		}
-		return serviceManger, nil
+		return withBandwidthLimitIfNeeded(cliConfig, serviceManger)
 	}
 }
 
@@ -125,9 +138,33 @@ func AddTrailingArtifactoryIfNeeded(url string) string {
 }
 
 func UploadFiles(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return UploadFilesWithProps(fileName, repositoryName, "", servicesManager)
+}
+
+// Uploads the file the same way as UploadFiles, and sets the given properties (in the "key1=value1;key2=value2" format) on it.
+// The file is uploaded in a single request, the UploadParams of jfrog-client-go v1.18.1 have no multipart chunk size and
+// split count settings.
+func UploadFilesWithProps(fileName string, repositoryName string, props string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return uploadFiles(fileName, repositoryName, props, services.NewUploadParams().MinChecksumDeploy, servicesManager)
+}
+
+// Uploads the file the same way as UploadFiles, but always sends its content. Files of at least 10KB are otherwise
+// deployed by their checksum once Artifactory stores the same content, so uploading a file again transfers no data.
+// It is used by the measured and the warm-up uploads, which upload the same files more than once.
+func UploadFilesWithoutChecksumDeploy(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return uploadFiles(fileName, repositoryName, "", math.MaxInt64, servicesManager)
+}
+
+// Files of at least minChecksumDeploy bytes are first deployed by their checksum.
+func uploadFiles(fileName string, repositoryName string, props string, minChecksumDeploy int64, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	targetProps, err := utils.ParseProperties(props)
+	if err != nil {
+		return 0, err
+	}
 	up := services.NewUploadParams()
-	up.CommonParams = &utils.CommonParams{Pattern: filepath.Join(fileName), Recursive: false, Target: repositoryName}
+	up.CommonParams = &utils.CommonParams{Pattern: filepath.Join(fileName), Recursive: false, Target: repositoryName, TargetProps: targetProps}
 	up.Flat = true
+	up.MinChecksumDeploy = minChecksumDeploy
 	start := time.Now()
 	totalSucceeded, totalFailed, err := servicesManager.UploadFiles(up)
 	end := time.Since(start)
@@ -138,17 +175,200 @@ func UploadFiles(fileName string, repositoryName string, servicesManager artifac
 }
 
 func DownloadFiles(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	defaultParams := services.NewDownloadParams()
+	return DownloadFilesWithSplit(fileName, repositoryName, defaultParams.SplitCount, defaultParams.MinSplitSize, servicesManager)
+}
+
+// Downloads the file the same way as DownloadFiles, files of at least minSplitSize KB are downloaded in splitCount
+// concurrent parts using range requests. A split count of 0 disables the split.
+func DownloadFilesWithSplit(fileName string, repositoryName string, splitCount int, minSplitSize int64, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	// Every download is written to its own directory which is discarded afterwards, otherwise the next download of the same
+	// artifact is skipped as it already exists locally, and concurrent downloads of the same artifact override each other.
+	err := os.MkdirAll(DownloadDirectory, os.ModePerm)
+	if err != nil {
+		return 0, err
+	}
+	target, err := ioutil.TempDir(DownloadDirectory, "download")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(target)
 	dl := services.NewDownloadParams()
-	dl.CommonParams = &utils.CommonParams{Pattern: filepath.Join(filepath.Base(fileName)), Recursive: false, Target: repositoryName}
+	dl.CommonParams = &utils.CommonParams{Pattern: GetArtifactPath(repositoryName, fileName), Recursive: false, Target: clientutils.AddTrailingSlashIfNeeded(target)}
+	dl.Flat = true
+	dl.SplitCount = splitCount
+	dl.MinSplitSize = minSplitSize
 	start := time.Now()
-	totalSucceeded, totalFailed, err := servicesManager.DownloadFiles(dl)
+	totalSucceeded, _, err := servicesManager.DownloadFiles(dl)
 	end := time.Since(start)
-	if totalFailed > 0 && totalSucceeded == 0 || err != nil {
+	// A pattern that matches no artifact is not an error for the services manager, but nothing was measured.
+	if totalSucceeded == 0 || err != nil {
 		return 0, errors.New("Failed to download files from Artifactory")
 	}
 	return end, nil
 }
 
+// Runs the AQL query and returns the time it took, including reading the response, and the number of items found.
+func SearchAql(query string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, int, error) {
+	start := time.Now()
+	reader, err := servicesManager.Aql(query)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer reader.Close()
+	body, err := ioutil.ReadAll(reader)
+	end := time.Since(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	var response struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return 0, 0, errors.New("Failed to parse the AQL response - " + err.Error())
+	}
+	return end, len(response.Results), nil
+}
+
+// Searches the files matching the pattern and the properties (in the "key1=value1;key2=value2" format, or empty),
+// and returns the time it took and the number of items found.
+func SearchPattern(pattern string, props string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, int, error) {
+	params := services.NewSearchParams()
+	params.CommonParams = &utils.CommonParams{Pattern: pattern, Props: props, Recursive: true}
+	start := time.Now()
+	reader, err := servicesManager.SearchFiles(params)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer reader.Close()
+	length, err := reader.Length()
+	end := time.Since(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	return end, length, nil
+}
+
+// Sets the properties (in the "key1=value1;key2=value2" format) on the uploaded file.
+func SetProps(fileName string, repositoryName string, props string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return measurePropsOperation(fileName, repositoryName, props, servicesManager.SetProps)
+}
+
+// Deletes the properties with the given keys (in the "key1,key2" format) from the uploaded file.
+func DeleteProps(fileName string, repositoryName string, keys string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return measurePropsOperation(fileName, repositoryName, keys, servicesManager.DeleteProps)
+}
+
+// Reads the properties of the uploaded file and returns the time it took and the number of properties found.
+func GetProps(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, int, error) {
+	start := time.Now()
+	itemProps, err := servicesManager.GetItemProps(GetArtifactPath(repositoryName, fileName))
+	end := time.Since(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	// No properties were found on the file
+	if itemProps == nil {
+		return end, 0, nil
+	}
+	return end, len(itemProps.Properties), nil
+}
+
+func measurePropsOperation(fileName string, repositoryName string, props string, operation func(services.PropsParams) (int, error)) (time.Duration, error) {
+	reader, err := newArtifactReader(fileName, repositoryName)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	params := services.NewPropsParams()
+	params.Reader = reader
+	params.Props = props
+	start := time.Now()
+	totalSucceeded, err := operation(params)
+	end := time.Since(start)
+	if totalSucceeded == 0 || err != nil {
+		return 0, errors.New("Failed to update the properties of [" + GetArtifactPath(repositoryName, fileName) + "] in Artifactory")
+	}
+	return end, nil
+}
+
+// The properties operations are executed on search results, so instead of measuring a search as well, the uploaded file
+// is written as the single search result.
+func newArtifactReader(fileName string, repositoryName string) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	writer.Write(utils.ResultItem{Repo: repositoryName, Path: ".", Name: filepath.Base(fileName), Type: "file"})
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+// Copies, or moves if move is true, the uploaded file to the root of the destination repository.
+func MoveCopyFiles(fileName string, repositoryName string, destinationRepositoryName string, move bool, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return moveCopyPattern(GetArtifactPath(repositoryName, fileName), destinationRepositoryName+"/", true, move, servicesManager)
+}
+
+// Copies, or moves if move is true, the given folder of the repository with all the files under it to the destination
+// repository, keeping the folder structure.
+func MoveCopyFolder(folderName string, repositoryName string, destinationRepositoryName string, move bool, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return moveCopyPattern(repositoryName+"/"+folderName+"/", destinationRepositoryName+"/", false, move, servicesManager)
+}
+
+// The measured time includes searching the paths to copy or move, the same as the copy and move commands of JFrog CLI do.
+func moveCopyPattern(pattern string, target string, flat bool, move bool, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	params := services.NewMoveCopyParams()
+	params.CommonParams = &utils.CommonParams{Pattern: pattern, Target: target, Recursive: true}
+	params.Flat = flat
+	operation := servicesManager.Copy
+	if move {
+		operation = servicesManager.Move
+	}
+	start := time.Now()
+	successCount, failedCount, err := operation(params)
+	end := time.Since(start)
+	if successCount == 0 || failedCount > 0 || err != nil {
+		return 0, errors.New("Failed to copy or move [" + pattern + "] to [" + target + "] in Artifactory")
+	}
+	return end, nil
+}
+
+func DeleteFiles(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return deletePattern(GetArtifactPath(repositoryName, fileName), servicesManager)
+}
+
+// Deletes the given folder of the repository with all the files under it, using a single delete call.
+func DeleteFolder(folderName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	return deletePattern(repositoryName+"/"+folderName+"/", servicesManager)
+}
+
+// The measured time includes searching the paths to delete, the same as the delete command of JFrog CLI does.
+func deletePattern(pattern string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	params := services.NewDeleteParams()
+	params.CommonParams = &utils.CommonParams{Pattern: pattern, Recursive: true}
+	start := time.Now()
+	reader, err := servicesManager.GetPathsToDelete(params)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	totalDeleted, err := servicesManager.DeleteFiles(reader)
+	end := time.Since(start)
+	if totalDeleted == 0 || err != nil {
+		return 0, errors.New("Failed to delete files from Artifactory")
+	}
+	return end, nil
+}
+
+// Returns the path of the uploaded file inside the repository, in the <repo>/<path> format.
+func GetArtifactPath(repositoryName string, fileName string) string {
+	return path.Join(repositoryName, filepath.Base(fileName))
+}
+
 func DeleteRepository(repo string, servicesManager artifactory.ArtifactoryServicesManager) error {
 	log.Info("Deleting the repository " + repo)
 	err := servicesManager.DeleteRepository(repo)
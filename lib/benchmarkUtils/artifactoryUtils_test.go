@@ -1,9 +1,27 @@
 package benchmarkUtils
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,3 +52,524 @@ func TestGetReadinessEndpointPerUrl(t *testing.T) {
 	assert.Equal(t, GetReadinessEndpointPerUrl("https://tamir_test.jfrog.io/artifactory"), "/api/v1/system/readiness")
 	assert.Equal(t, GetReadinessEndpointPerUrl("https://tamir_test.jfrog.io/artifactory/"), "api/v1/system/readiness")
 }
+
+type fakeArtifactory struct {
+	*httptest.Server
+	mutex sync.Mutex
+	// The artifacts served by the fake server, keyed by their path in the <repo>/<path> format.
+	artifacts map[string][]byte
+	// The paths of the artifacts that were downloaded, in the order they were requested.
+	downloaded []string
+	// The paths of the artifacts that were uploaded, in the order they were requested.
+	uploaded []string
+	// The paths of the artifacts whose deploy by checksum was attempted, which the fake server never stores.
+	checksumDeploys []string
+	// The paths of the artifacts that were deleted, in the order they were requested.
+	deleted []string
+	// The properties of the artifacts, keyed by their path.
+	props map[string]url.Values
+	// The published build-info documents, keyed by their <name>/<number>.
+	builds map[string][]byte
+	// The number of requests to recalculate the storage info.
+	storageRefreshes int
+	// The number of range requests, which are sent when downloading a file in parts.
+	rangeRequests int
+	// The Open Metrics served by the fake server, the metrics endpoint isn't found when they are empty.
+	metrics string
+}
+
+// Matches the repo, path and name criteria of the AQL queries built for patterns, a criterion is either a value or a $match wildcard.
+var aqlCriteriaRegexp = regexp.MustCompile(`\{"repo":"([^"]*)","path":(?:"([^"]*)"|\{"\$match":"([^"]*)"\}),"name":(?:"([^"]*)"|\{"\$match":"([^"]*)"\})`)
+
+func aqlMatches(aql string, artifactPath string) bool {
+	repo, itemPath, name := splitArtifactPath(artifactPath)
+	for _, criteria := range aqlCriteriaRegexp.FindAllStringSubmatch(aql, -1) {
+		pathMatch, _ := path.Match(criteria[2]+criteria[3], itemPath)
+		nameMatch, _ := path.Match(criteria[4]+criteria[5], name)
+		if criteria[1] == repo && pathMatch && nameMatch {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits an artifact path in the <repo>/<path> format to the repo, path and name of the artifact as returned by AQL.
+func splitArtifactPath(artifactPath string) (repo string, itemPath string, name string) {
+	parts := strings.SplitN(artifactPath, "/", 2)
+	itemPath, name = path.Split(parts[1])
+	if itemPath == "" {
+		return parts[0], ".", name
+	}
+	return parts[0], strings.TrimSuffix(itemPath, "/"), name
+}
+
+// Serves the subset of the Artifactory REST API used by the services manager for downloading artifacts.
+func newFakeArtifactory(t *testing.T, artifacts map[string][]byte) (*fakeArtifactory, artifactory.ArtifactoryServicesManager) {
+	fake := &fakeArtifactory{artifacts: artifacts, props: map[string]url.Values{}, builds: map[string][]byte{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: fake.URL + "/artifactory/"}
+	// A single thread, the same as the services managers of the commands.
+	servicesManager, err := rtUtils.CreateServiceManagerWithThreads(serverDetails, false, 1, 0, 0)
+	if err != nil {
+		fake.Close()
+		t.Fatalf("Failed to create services manager: %v", err)
+	}
+	return fake, servicesManager
+}
+
+func (fake *fakeArtifactory) handle(w http.ResponseWriter, r *http.Request) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	requestPath := strings.TrimPrefix(r.URL.Path, "/artifactory/")
+	if strings.HasPrefix(requestPath, "api/storage/") {
+		fake.handleProps(w, r, strings.TrimPrefix(requestPath, "api/storage/"))
+		return
+	}
+	if strings.HasPrefix(requestPath, "api/copy/") || strings.HasPrefix(requestPath, "api/move/") {
+		fake.handleMoveCopy(w, r, requestPath)
+		return
+	}
+	if dockerRequest := dockerRequestRegexp.FindStringSubmatch(requestPath); dockerRequest != nil {
+		fake.handleDocker(w, r, dockerRequest[1], dockerRequest[2], dockerRequest[3])
+		return
+	}
+	if requestPath == "api/build" || strings.HasPrefix(requestPath, "api/build/") {
+		fake.handleBuild(w, r, strings.TrimPrefix(strings.TrimPrefix(requestPath, "api/build"), "/"))
+		return
+	}
+	if r.Method == http.MethodDelete {
+		deleted := false
+		for artifactPath := range fake.artifacts {
+			if artifactPath == requestPath || strings.HasPrefix(artifactPath, strings.TrimSuffix(requestPath, "/")+"/") {
+				delete(fake.artifacts, artifactPath)
+				fake.deleted = append(fake.deleted, artifactPath)
+				deleted = true
+			}
+		}
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method == http.MethodPut {
+		if r.Header.Get("X-Checksum-Deploy") == "true" {
+			fake.checksumDeploys = append(fake.checksumDeploys, requestPath)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The properties are sent as matrix parameters, i.e. <repo>/<path>;key1=value1;key2=value2
+		matrixParams := strings.Split(requestPath, ";")
+		requestPath = matrixParams[0]
+		fake.props[requestPath] = url.Values{}
+		for _, param := range matrixParams[1:] {
+			keyValue := strings.SplitN(param, "=", 2)
+			fake.props[requestPath].Add(keyValue[0], keyValue[1])
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		fake.uploaded = append(fake.uploaded, requestPath)
+		if r.Header.Get("X-Explode-Archive") == "true" {
+			if !fake.explode(path.Dir(requestPath), data) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fake.artifacts[requestPath] = data
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+		return
+	}
+	switch requestPath {
+	case "api/system/version":
+		fmt.Fprint(w, `{"version":"7.55.0"}`)
+	case "api/storageinfo":
+		fake.handleStorageInfo(w)
+	case "api/storageinfo/calculate":
+		fake.storageRefreshes++
+		w.WriteHeader(http.StatusAccepted)
+	case "api/v1/metrics":
+		if fake.metrics == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, fake.metrics)
+	case "api/search/aql":
+		body, _ := ioutil.ReadAll(r.Body)
+		var results []string
+		for artifactPath, data := range fake.artifacts {
+			if aqlMatches(string(body), artifactPath) {
+				repo, itemPath, name := splitArtifactPath(artifactPath)
+				results = append(results, fmt.Sprintf(`{"repo":"%s","path":"%s","name":"%s","type":"file","size":%d}`, repo, itemPath, name, len(data)))
+			}
+		}
+		fmt.Fprintf(w, `{"results":[%s]}`, strings.Join(results, ","))
+	default:
+		data, ok := fake.artifacts[requestPath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Range") != "" {
+			fake.rangeRequests++
+		} else if r.Method == http.MethodGet {
+			fake.downloaded = append(fake.downloaded, requestPath)
+		}
+		http.ServeContent(w, r, path.Base(requestPath), time.Time{}, bytes.NewReader(data))
+	}
+}
+
+// Serves the number of artifacts and the number of files and used bytes of every repository, the counts are formatted with
+// thousands separators as Artifactory does.
+func (fake *fakeArtifactory) handleStorageInfo(w http.ResponseWriter) {
+	filesCounts := map[string]int{}
+	usedSpace := map[string]int{}
+	for artifactPath, data := range fake.artifacts {
+		repo, _, _ := splitArtifactPath(artifactPath)
+		filesCounts[repo]++
+		usedSpace[repo] += len(data)
+	}
+	var repositories []string
+	for repo, filesCount := range filesCounts {
+		repositories = append(repositories, fmt.Sprintf(`{"repoKey":"%s","filesCount":%d,"itemsCount":%d,"usedSpaceInBytes":%d}`,
+			repo, filesCount, filesCount, usedSpace[repo]))
+	}
+	fmt.Fprintf(w, `{"binariesSummary":{"binariesCount":"1,%03d","itemsCount":"%d","artifactsCount":"%d"},"repositoriesSummaryList":[%s]}`,
+		len(fake.artifacts), len(fake.artifacts), len(fake.artifacts), strings.Join(repositories, ","))
+}
+
+func (fake *fakeArtifactory) handleProps(w http.ResponseWriter, r *http.Request, artifactPath string) {
+	if _, ok := fake.artifacts[artifactPath]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	// The properties are separated by semicolons, which url.ParseQuery rejects.
+	var properties string
+	for _, param := range strings.Split(r.URL.RawQuery, "&") {
+		if strings.HasPrefix(param, "properties=") {
+			properties, _ = url.QueryUnescape(strings.TrimPrefix(param, "properties="))
+		}
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if len(fake.props[artifactPath]) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"status":404,"message":"No properties could be found."}]}`)
+			return
+		}
+		body, _ := json.Marshal(map[string]url.Values{"properties": fake.props[artifactPath]})
+		w.Write(body)
+	case http.MethodPut:
+		if fake.props[artifactPath] == nil {
+			fake.props[artifactPath] = url.Values{}
+		}
+		for _, prop := range strings.Split(properties, ";") {
+			keyValue := strings.SplitN(prop, "=", 2)
+			fake.props[artifactPath][keyValue[0]] = strings.Split(keyValue[1], ",")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		for _, key := range strings.Split(properties, ",") {
+			fake.props[artifactPath].Del(key)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Handles the api/copy/<repo>/<path>?to=<repo>/<path> and api/move/<repo>/<path>?to=<repo>/<path> requests.
+func (fake *fakeArtifactory) handleMoveCopy(w http.ResponseWriter, r *http.Request, requestPath string) {
+	parts := strings.SplitN(requestPath, "/", 3)
+	operation, sourcePath, destinationPath := parts[1], parts[2], r.URL.Query().Get("to")
+	data, ok := fake.artifacts[sourcePath]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	fake.artifacts[destinationPath] = data
+	if operation == "move" {
+		delete(fake.artifacts, sourcePath)
+	}
+	fmt.Fprint(w, `{"messages":[]}`)
+}
+
+// Stores the entries of the zip archive in the given folder, the same way Artifactory explodes an uploaded archive.
+func (fake *fakeArtifactory) explode(folder string, data []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, entry := range archive.File {
+		reader, err := entry.Open()
+		if err != nil {
+			return false
+		}
+		fake.artifacts[path.Join(folder, entry.Name)], _ = ioutil.ReadAll(reader)
+		reader.Close()
+	}
+	return true
+}
+
+// Matches the Docker Registry v2 API requests of a repository, i.e. api/docker/<repo>/v2/<name>/<blobs or manifests request>.
+var dockerRequestRegexp = regexp.MustCompile(`^api/docker/([^/]+)/v2/(.+)/(blobs/uploads/[^/]*|blobs/[^/]+|manifests/[^/]+)$`)
+
+// Handles the blobs uploads, which are always monolithic, and the blobs and manifests pulls. The blobs are stored in the
+// artifacts as <repo>/<name>/<digest>, and the manifests as <repo>/<name>/<tag>/manifest.json.
+func (fake *fakeArtifactory) handleDocker(w http.ResponseWriter, r *http.Request, repo string, name string, request string) {
+	switch {
+	case r.Method == http.MethodPost && request == "blobs/uploads/":
+		w.Header().Set("Location", "/artifactory/api/docker/"+repo+"/v2/"+name+"/blobs/uploads/"+strconv.Itoa(len(fake.uploaded)))
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut && strings.HasPrefix(request, "blobs/uploads/"):
+		data, _ := ioutil.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if digest != fmt.Sprintf("sha256:%x", sha256.Sum256(data)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fake.artifacts[repo+"/"+name+"/"+digest] = data
+		fake.uploaded = append(fake.uploaded, repo+"/"+name+"/"+digest)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && strings.HasPrefix(request, "manifests/"):
+		data, _ := ioutil.ReadAll(r.Body)
+		manifestPath := repo + "/" + name + "/" + strings.TrimPrefix(request, "manifests/") + "/manifest.json"
+		fake.artifacts[manifestPath] = data
+		fake.uploaded = append(fake.uploaded, manifestPath)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet:
+		artifactPath := repo + "/" + name + "/" + strings.TrimPrefix(request, "blobs/")
+		if strings.HasPrefix(request, "manifests/") {
+			artifactPath = repo + "/" + name + "/" + strings.TrimPrefix(request, "manifests/") + "/manifest.json"
+		}
+		data, ok := fake.artifacts[artifactPath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fake.downloaded = append(fake.downloaded, artifactPath)
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// Handles publishing build-info to api/build, getting it from api/build/<name>/<number> and deleting api/build/<name>.
+func (fake *fakeArtifactory) handleBuild(w http.ResponseWriter, r *http.Request, buildPath string) {
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		var build struct {
+			Name   string `json:"name"`
+			Number string `json:"number"`
+		}
+		if err := json.Unmarshal(data, &build); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fake.builds[build.Name+"/"+build.Number] = data
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		data, ok := fake.builds[buildPath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"uri":"%s/artifactory/api/build/%s","buildInfo":%s}`, fake.URL, buildPath, data)
+	case http.MethodDelete:
+		for build := range fake.builds {
+			if strings.HasPrefix(build, buildPath+"/") {
+				delete(fake.builds, build)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestGetArtifactPath(t *testing.T) {
+	assert.Equal(t, "benchmark-dl-tests/File1.txt", GetArtifactPath("benchmark-dl-tests", "/tmp/testfiles/File1.txt"))
+	assert.Equal(t, "benchmark-dl-tests/File2.txt", GetArtifactPath("benchmark-dl-tests", "File2.txt"))
+}
+
+func TestDownloadFiles(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-dl-tests/File1.txt": []byte("first artifact"),
+		"benchmark-dl-tests/File2.txt": []byte("second artifact"),
+		"other-repo/File1.txt":         []byte("artifact from another repository"),
+	})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+
+	// Downloading the same file twice makes sure it is fetched again and not skipped as an existing local file.
+	for _, fileName := range []string{"/tmp/testfiles/File1.txt", "/tmp/testfiles/File2.txt", "/tmp/testfiles/File1.txt"} {
+		_, err := DownloadFiles(fileName, "benchmark-dl-tests", servicesManager)
+		assert.NoError(t, err)
+		downloads, err := ioutil.ReadDir(DownloadDirectory)
+		assert.NoError(t, err)
+		assert.Empty(t, downloads, "Expected the downloaded file to be discarded")
+	}
+	assert.Equal(t, []string{"benchmark-dl-tests/File1.txt", "benchmark-dl-tests/File2.txt", "benchmark-dl-tests/File1.txt"}, fake.downloaded)
+
+	_, err := DownloadFiles("/tmp/testfiles/File3.txt", "benchmark-dl-tests", servicesManager)
+	assert.Error(t, err)
+}
+
+func TestDownloadFilesWithSplit(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-dl-tests/File1.txt": bytes.Repeat([]byte("a"), 3000),
+	})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+
+	_, err := DownloadFilesWithSplit("/tmp/testfiles/File1.txt", "benchmark-dl-tests", 3, 1, servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fake.rangeRequests)
+	assert.Empty(t, fake.downloaded)
+
+	// The file is smaller than the minimal split size, so it is downloaded in a single request.
+	_, err = DownloadFilesWithSplit("/tmp/testfiles/File1.txt", "benchmark-dl-tests", 3, 5, servicesManager)
+	assert.NoError(t, err)
+	_, err = DownloadFilesWithSplit("/tmp/testfiles/File1.txt", "benchmark-dl-tests", 0, 1, servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fake.rangeRequests)
+	assert.Len(t, fake.downloaded, 2)
+}
+
+func TestDeleteFiles(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-del-tests/File1.txt":      []byte("first artifact"),
+		"benchmark-del-tests/File2.txt":      []byte("second artifact"),
+		"benchmark-del-tests/bulk/File1.txt": []byte("first artifact"),
+		"benchmark-del-tests/bulk/File2.txt": []byte("second artifact"),
+	})
+	defer fake.Close()
+
+	_, err := DeleteFiles("/tmp/testfiles/File1.txt", "benchmark-del-tests", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"benchmark-del-tests/File1.txt"}, fake.deleted)
+	_, err = DeleteFiles("/tmp/testfiles/File1.txt", "benchmark-del-tests", servicesManager)
+	assert.Error(t, err, "Expected an error when deleting a file that doesn't exist")
+
+	_, err = DeleteFolder("bulk", "benchmark-del-tests", servicesManager)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"benchmark-del-tests/File1.txt", "benchmark-del-tests/bulk/File1.txt", "benchmark-del-tests/bulk/File2.txt"}, fake.deleted)
+	assert.Contains(t, fake.artifacts, "benchmark-del-tests/File2.txt")
+}
+
+func TestUploadFilesWithProps(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 1)
+	defer os.RemoveAll(localDir)
+
+	_, err := UploadFilesWithProps(fileNames[0], "benchmark-search-tests", "benchmark.index=1;benchmark.group=1", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"benchmark-search-tests/File1.txt"}, fake.uploaded)
+	assert.Equal(t, url.Values{"benchmark.index": {"1"}, "benchmark.group": {"1"}}, fake.props["benchmark-search-tests/File1.txt"])
+
+	_, err = UploadFilesWithProps(fileNames[0], "benchmark-search-tests", "invalid", servicesManager)
+	assert.Error(t, err)
+}
+
+func TestUploadFilesWithoutChecksumDeploy(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, err := ioutil.TempDir("", "benchmark")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(localDir)
+	// Large enough to be deployed by its checksum by default.
+	fileName := path.Join(localDir, "File1.txt")
+	assert.NoError(t, ioutil.WriteFile(fileName, make([]byte, 20*1024), os.ModePerm))
+
+	_, err = UploadFiles(fileName, "benchmark-up-tests", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"benchmark-up-tests/File1.txt"}, fake.checksumDeploys)
+
+	_, err = UploadFilesWithoutChecksumDeploy(fileName, "benchmark-up-tests", servicesManager)
+	assert.NoError(t, err)
+	assert.Len(t, fake.checksumDeploys, 1)
+	assert.Equal(t, []string{"benchmark-up-tests/File1.txt", "benchmark-up-tests/File1.txt"}, fake.uploaded)
+}
+
+func TestSearchAql(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-search-tests/File1.txt":  []byte("first artifact"),
+		"benchmark-search-tests/File2.txt":  []byte("second artifact"),
+		"benchmark-search-tests/File10.txt": []byte("tenth artifact"),
+	})
+	defer fake.Close()
+
+	_, items, err := SearchAql(`items.find({"repo":"benchmark-search-tests","path":".","name":{"$match":"File1*"}})`, servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, items)
+	_, items, err = SearchAql(`items.find({"repo":"other-repo","path":".","name":{"$match":"*"}})`, servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, items)
+}
+
+func TestSearchPattern(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-search-tests/File1.txt":  []byte("first artifact"),
+		"benchmark-search-tests/File2.txt":  []byte("second artifact"),
+		"benchmark-search-tests/File10.txt": []byte("tenth artifact"),
+	})
+	defer fake.Close()
+
+	_, items, err := SearchPattern("benchmark-search-tests/*", "", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, items)
+	_, items, err = SearchPattern("benchmark-search-tests/File2*", "", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, items)
+}
+
+func TestPropsOperations(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{"benchmark-props-tests/File1.txt": []byte("first artifact")})
+	defer fake.Close()
+
+	_, propsFound, err := GetProps("/tmp/testfiles/File1.txt", "benchmark-props-tests", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, propsFound)
+
+	_, err = SetProps("/tmp/testfiles/File1.txt", "benchmark-props-tests", "benchmark.prop1=value1;benchmark.prop2=value2", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"benchmark.prop1": {"value1"}, "benchmark.prop2": {"value2"}}, fake.props["benchmark-props-tests/File1.txt"])
+	_, propsFound, err = GetProps("/tmp/testfiles/File1.txt", "benchmark-props-tests", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, propsFound)
+
+	_, err = DeleteProps("/tmp/testfiles/File1.txt", "benchmark-props-tests", "benchmark.prop1", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"benchmark.prop2": {"value2"}}, fake.props["benchmark-props-tests/File1.txt"])
+
+	_, err = SetProps("/tmp/testfiles/File2.txt", "benchmark-props-tests", "benchmark.prop1=value1", servicesManager)
+	assert.Error(t, err, "Expected an error when setting properties on a file that doesn't exist")
+}
+
+func TestMoveCopyFiles(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-copy-tests/File1.txt":        []byte("first artifact"),
+		"benchmark-copy-tests/File2.txt":        []byte("second artifact"),
+		"benchmark-copy-tests/folder/File1.txt": []byte("first artifact"),
+	})
+	defer fake.Close()
+
+	_, err := MoveCopyFiles("/tmp/testfiles/File1.txt", "benchmark-copy-tests", "benchmark-copy-dest-tests", false, servicesManager)
+	assert.NoError(t, err)
+	_, err = MoveCopyFiles("/tmp/testfiles/File2.txt", "benchmark-copy-tests", "benchmark-copy-dest-tests", true, servicesManager)
+	assert.NoError(t, err)
+	_, err = MoveCopyFolder("folder", "benchmark-copy-tests", "benchmark-copy-dest-tests", true, servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"benchmark-copy-tests/File1.txt":             []byte("first artifact"),
+		"benchmark-copy-dest-tests/File1.txt":        []byte("first artifact"),
+		"benchmark-copy-dest-tests/File2.txt":        []byte("second artifact"),
+		"benchmark-copy-dest-tests/folder/File1.txt": []byte("first artifact"),
+	}, fake.artifacts)
+
+	_, err = MoveCopyFiles("/tmp/testfiles/File2.txt", "benchmark-copy-tests", "benchmark-copy-dest-tests", true, servicesManager)
+	assert.Error(t, err, "Expected an error when moving a file that doesn't exist")
+}
@@ -0,0 +1,172 @@
+package benchmarkUtils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The scopes of the bandwidth limit, either every upload or download stream is limited separately, or all of them share
+// the limit.
+const (
+	WorkerBandwidthScope = "worker"
+	TotalBandwidthScope  = "total"
+)
+
+// The largest chunk read at once from a throttled stream, so the limit is applied smoothly rather than in bursts.
+const throttledReadSize = 32 * 1024
+
+var bandwidthRegexp = regexp.MustCompile(`^(\d+(?:\.\d+)?)(B|KB|MB|GB)/S$`)
+
+var bandwidthUnits = map[string]float64{"B": 1, "KB": 1024, "MB": 1024 * 1024, "GB": 1024 * 1024 * 1024}
+
+// Parses a bandwidth such as 512KB/s or 10MB/s, and returns it in bytes per second.
+func ParseBandwidth(bandwidth string) (float64, error) {
+	match := bandwidthRegexp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(bandwidth)))
+	if match == nil {
+		return 0, errors.New("Bandwidth limit must be a number followed by B/s, KB/s, MB/s or GB/s, such as 10MB/s")
+	}
+	value, _ := strconv.ParseFloat(match[1], 64)
+	if value <= 0 {
+		return 0, errors.New("Bandwidth limit must be positive")
+	}
+	return value * bandwidthUnits[match[2]], nil
+}
+
+// Paces the bytes transferred through it so they don't exceed the given number of bytes per second.
+type bandwidthLimiter struct {
+	mutex          sync.Mutex
+	bytesPerSecond float64
+	next           time.Time
+}
+
+func newBandwidthLimiter(bytesPerSecond float64) *bandwidthLimiter {
+	return &bandwidthLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+// Waits until the given number of bytes is allowed to be transferred, after the bytes that were already reserved.
+func (limiter *bandwidthLimiter) wait(bytes int) {
+	limiter.mutex.Lock()
+	now := time.Now()
+	if limiter.next.Before(now) {
+		limiter.next = now
+	}
+	delay := limiter.next.Sub(now)
+	limiter.next = limiter.next.Add(time.Duration(float64(bytes) / limiter.bytesPerSecond * float64(time.Second)))
+	limiter.mutex.Unlock()
+	time.Sleep(delay)
+}
+
+type throttledReader struct {
+	reader  io.Reader
+	limiter *bandwidthLimiter
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > throttledReadSize {
+		p = p[:throttledReadSize]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.limiter.wait(n)
+	}
+	return n, err
+}
+
+// A progress manager which doesn't display anything, and only throttles the upload and download streams of the files.
+// The parts of a file downloaded concurrently share the limit of the file.
+type bandwidthLimitProgress struct {
+	mutex          sync.Mutex
+	bytesPerSecond float64
+	// Shared by all the streams if the limit is total, otherwise every stream has its own limiter.
+	totalLimiter *bandwidthLimiter
+	lastId       int
+	progresses   map[int]*bandwidthLimitFileProgress
+}
+
+func NewBandwidthLimitProgress(bytesPerSecond float64, scope string) ioutils.ProgressMgr {
+	progress := &bandwidthLimitProgress{bytesPerSecond: bytesPerSecond, progresses: map[int]*bandwidthLimitFileProgress{}}
+	if scope == TotalBandwidthScope {
+		progress.totalLimiter = newBandwidthLimiter(bytesPerSecond)
+	}
+	return progress
+}
+
+func (p *bandwidthLimitProgress) NewProgressReader(total int64, label, path string) ioutils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.lastId++
+	limiter := p.totalLimiter
+	if limiter == nil {
+		limiter = newBandwidthLimiter(p.bytesPerSecond)
+	}
+	progress := &bandwidthLimitFileProgress{id: p.lastId, limiter: limiter}
+	p.progresses[p.lastId] = progress
+	return progress
+}
+
+func (p *bandwidthLimitProgress) GetProgress(id int) ioutils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.progresses[id]
+}
+
+func (p *bandwidthLimitProgress) RemoveProgress(id int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.progresses, id)
+}
+
+func (p *bandwidthLimitProgress) SetProgressState(id int, state string) {}
+func (p *bandwidthLimitProgress) Quit() error                           { return nil }
+func (p *bandwidthLimitProgress) IncGeneralProgressTotalBy(n int64)     {}
+func (p *bandwidthLimitProgress) SetHeadlineMsg(msg string)             {}
+func (p *bandwidthLimitProgress) ClearHeadlineMsg()                     {}
+func (p *bandwidthLimitProgress) InitProgressReaders()                  {}
+
+type bandwidthLimitFileProgress struct {
+	id      int
+	limiter *bandwidthLimiter
+}
+
+func (p *bandwidthLimitFileProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	return &throttledReader{reader: reader, limiter: p.limiter}
+}
+func (p *bandwidthLimitFileProgress) Abort()     {}
+func (p *bandwidthLimitFileProgress) GetId() int { return p.id }
+
+// A services manager whose uploads and downloads are throttled by its progress manager. The services managers of the
+// concurrent workers are created with the same progress manager, so they share its limit.
+type throttledServicesManager struct {
+	artifactory.ArtifactoryServicesManager
+	progress ioutils.ProgressMgr
+}
+
+// Returns a services manager with the same config as the given one, whose uploads and downloads are throttled to the
+// bandwidth limit of the config.
+func WithBandwidthLimit(cliConfig *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager) (artifactory.ArtifactoryServicesManager, error) {
+	config := servicesManager.GetConfig()
+	progress := NewBandwidthLimitProgress(cliConfig.BandwidthLimit, cliConfig.BandwidthScope)
+	throttledManager, err := newServicesManagerWithProgress(config, config.GetThreads(), progress)
+	if err != nil {
+		return nil, err
+	}
+	return &throttledServicesManager{ArtifactoryServicesManager: throttledManager, progress: progress}, nil
+}
+
+func withBandwidthLimitIfNeeded(cliConfig *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager) (artifactory.ArtifactoryServicesManager, error) {
+	if cliConfig.BandwidthLimit == 0 {
+		return servicesManager, nil
+	}
+	log.Info(fmt.Sprintf("Throttling the uploads and downloads to %.2fKB/s, the limit scope is %s", cliConfig.BandwidthLimit/1024, cliConfig.BandwidthScope))
+	return WithBandwidthLimit(cliConfig, servicesManager)
+}
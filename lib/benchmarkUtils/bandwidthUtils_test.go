@@ -0,0 +1,108 @@
+package benchmarkUtils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	bandwidth, err := ParseBandwidth("10MB/s")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10*1024*1024), bandwidth)
+	bandwidth, err = ParseBandwidth("512kb/s")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(512*1024), bandwidth)
+	bandwidth, err = ParseBandwidth("1.5GB/s")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5*1024*1024*1024, bandwidth)
+	for _, invalid := range []string{"", "10", "10MB", "MB/s", "0MB/s", "-1MB/s", "10Mb/sec"} {
+		_, err = ParseBandwidth(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestThrottledReader(t *testing.T) {
+	data := make([]byte, 100*1024)
+	reader := &throttledReader{reader: bytes.NewReader(data), limiter: newBandwidthLimiter(400 * 1024)}
+	start := time.Now()
+	read, err := ioutil.ReadAll(reader)
+	elapsed := time.Since(start)
+	assert.NoError(t, err)
+	assert.Equal(t, data, read)
+	// The first chunk isn't delayed, and the rest of the 100KB takes about 0.17 seconds at 400KB/s.
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond)
+	assert.Less(t, elapsed, 2*time.Second)
+}
+
+func TestBandwidthLimitScope(t *testing.T) {
+	workerProgress := NewBandwidthLimitProgress(1024, WorkerBandwidthScope)
+	first := workerProgress.NewProgressReader(0, "", "").(*bandwidthLimitFileProgress)
+	second := workerProgress.NewProgressReader(0, "", "").(*bandwidthLimitFileProgress)
+	assert.NotSame(t, first.limiter, second.limiter)
+	assert.Same(t, first, workerProgress.GetProgress(first.GetId()))
+	workerProgress.RemoveProgress(first.GetId())
+	assert.Nil(t, workerProgress.GetProgress(first.GetId()))
+
+	totalProgress := NewBandwidthLimitProgress(1024, TotalBandwidthScope)
+	first = totalProgress.NewProgressReader(0, "", "").(*bandwidthLimitFileProgress)
+	second = totalProgress.NewProgressReader(0, "", "").(*bandwidthLimitFileProgress)
+	assert.Same(t, first.limiter, second.limiter)
+}
+
+func TestWithBandwidthLimit(t *testing.T) {
+	content := make([]byte, 256*1024)
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{"benchmark-dl-tests/File1.txt": content})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+	config := &BenchmarkConfig{BandwidthLimit: 1024 * 1024, BandwidthScope: WorkerBandwidthScope}
+	throttledManager, err := WithBandwidthLimit(config, servicesManager)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// 256KB take about 0.22 seconds at 1MB/s, after the first chunk.
+	duration, err := DownloadFiles("/tmp/testfiles/File1.txt", "benchmark-dl-tests", throttledManager)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, duration, 200*time.Millisecond)
+	assert.Equal(t, []string{"benchmark-dl-tests/File1.txt"}, fake.downloaded)
+
+	localDir, err := ioutil.TempDir("", "benchmark")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(localDir)
+	fileName := filepath.Join(localDir, "File2.txt")
+	assert.NoError(t, ioutil.WriteFile(fileName, content, 0644))
+	duration, err = UploadFiles(fileName, "benchmark-up-tests", throttledManager)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, duration, 200*time.Millisecond)
+	assert.Equal(t, content, fake.artifacts["benchmark-up-tests/File2.txt"])
+}
+
+func TestNewWorkersServicesManagers(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	config := &BenchmarkConfig{BandwidthLimit: 1024 * 1024, BandwidthScope: TotalBandwidthScope}
+	throttledManager, err := WithBandwidthLimit(config, servicesManager)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	workersServicesManagers, err := newWorkersServicesManagers(throttledManager, 2)
+	if !assert.NoError(t, err) || !assert.Len(t, workersServicesManagers, 2) {
+		return
+	}
+	assert.NotSame(t, workersServicesManagers[0], workersServicesManagers[1])
+	for _, workerServicesManager := range workersServicesManagers {
+		// The workers share the bandwidth limit of the throttled services manager.
+		if assert.IsType(t, &throttledServicesManager{}, workerServicesManager) {
+			assert.Same(t, throttledManager.(*throttledServicesManager).progress, workerServicesManager.(*throttledServicesManager).progress)
+		}
+	}
+}
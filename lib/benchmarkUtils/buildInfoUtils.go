@@ -0,0 +1,119 @@
+package benchmarkUtils
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Generates a build-info with the given number of modules, where each module has the given number of artifacts and
+// dependencies. The checksums are calculated from the artifacts and dependencies names, so they are unique per build.
+func GenerateBuildInfo(buildName string, buildNumber string, modules int, artifactsPerModule int, dependenciesPerModule int) *buildinfo.BuildInfo {
+	build := buildinfo.New()
+	build.Name = buildName
+	build.Number = buildNumber
+	build.Started = time.Now().Format(buildinfo.TimeFormat)
+	build.Agent = &buildinfo.Agent{Name: "benchmark"}
+	for i := 1; i <= modules; i++ {
+		module := buildinfo.Module{Id: fmt.Sprintf("benchmark:module%d:%s", i, buildNumber), Type: buildinfo.Generic}
+		for j := 1; j <= artifactsPerModule; j++ {
+			name := fmt.Sprintf("module%d-artifact%d.bin", i, j)
+			module.Artifacts = append(module.Artifacts, buildinfo.Artifact{Name: name, Type: "bin",
+				Path: path.Join(buildName, buildNumber, name), Checksum: getChecksum(buildName + "/" + buildNumber + "/" + name)})
+		}
+		for j := 1; j <= dependenciesPerModule; j++ {
+			id := fmt.Sprintf("benchmark:dependency%d:1.0.%d", j, i)
+			module.Dependencies = append(module.Dependencies, buildinfo.Dependency{Id: id, Type: "jar", Checksum: getChecksum(id)})
+		}
+		build.Modules = append(build.Modules, module)
+	}
+	return build
+}
+
+func getChecksum(value string) buildinfo.Checksum {
+	return buildinfo.Checksum{
+		Sha1:   fmt.Sprintf("%x", sha1.Sum([]byte(value))),
+		Md5:    fmt.Sprintf("%x", md5.Sum([]byte(value))),
+		Sha256: fmt.Sprintf("%x", sha256.Sum256([]byte(value))),
+	}
+}
+
+func PublishBuildInfo(build *buildinfo.BuildInfo, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	start := time.Now()
+	summary, err := servicesManager.PublishBuildInfo(build, "")
+	end := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if !summary.IsSucceeded() {
+		return 0, errors.New("Failed to publish build-info [" + build.Name + "/" + build.Number + "] to Artifactory")
+	}
+	return end, nil
+}
+
+// Retrieves the published build-info and returns the time it took and the number of its modules.
+func GetBuildInfo(buildName string, buildNumber string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, int, error) {
+	params := services.NewBuildInfoParams()
+	params.BuildName = buildName
+	params.BuildNumber = buildNumber
+	start := time.Now()
+	publishedBuild, found, err := servicesManager.GetBuildInfo(params)
+	end := time.Since(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return 0, 0, errors.New("Build-info [" + buildName + "/" + buildNumber + "] was not found in Artifactory")
+	}
+	return end, len(publishedBuild.BuildInfo.Modules), nil
+}
+
+// Deletes all the numbers of the build, without deleting the artifacts that were published by it.
+func DeleteBuild(buildName string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	log.Info("Deleting the build " + buildName)
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendDelete(serviceDetails.GetUrl()+"api/build/"+buildName+"?deleteAll=1&artifacts=0", nil, &httpClientsDetails)
+	if err != nil {
+		log.Error("Failed to delete build ["+buildName+"]", err)
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
+
+// Publishes Iterations build-info documents of the configured size with the numbers 1 to Iterations, and retrieves each
+// of them after it is published. The items of each result are the artifacts and dependencies of the build-info.
+func MeasureBuildInfoOperationTimes(st *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	items := st.Modules * (st.ArtifactsPerModule + st.DependenciesPerModule)
+	for i := 1; i <= st.Iterations; i++ {
+		buildNumber := strconv.Itoa(i)
+		build := GenerateBuildInfo(st.BuildName, buildNumber, st.Modules, st.ArtifactsPerModule, st.DependenciesPerModule)
+		duration, err := PublishBuildInfo(build, servicesManager)
+		if err != nil {
+			return err
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("publish-build", st.BuildName+"/"+buildNumber, items, duration))
+		duration, modulesFound, err := GetBuildInfo(st.BuildName, buildNumber, servicesManager)
+		if err != nil {
+			return err
+		}
+		if modulesFound != st.Modules {
+			return fmt.Errorf("Build-info [%s/%s] was retrieved with %d modules instead of %d", st.BuildName, buildNumber, modulesFound, st.Modules)
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("get-build", st.BuildName+"/"+buildNumber, items, duration))
+	}
+	return nil
+}
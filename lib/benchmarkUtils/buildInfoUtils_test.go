@@ -0,0 +1,59 @@
+package benchmarkUtils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfo(t *testing.T) {
+	build := GenerateBuildInfo("benchmark-build-tests", "1", 3, 4, 5)
+	assert.Equal(t, "benchmark-build-tests", build.Name)
+	assert.Equal(t, "1", build.Number)
+	assert.Len(t, build.Modules, 3)
+	for _, module := range build.Modules {
+		assert.Len(t, module.Artifacts, 4)
+		assert.Len(t, module.Dependencies, 5)
+	}
+	assert.Len(t, build.Modules[0].Artifacts[0].Sha1, 40)
+	assert.Len(t, build.Modules[0].Artifacts[0].Md5, 32)
+	assert.Len(t, build.Modules[0].Artifacts[0].Sha256, 64)
+	assert.NotEqual(t, build.Modules[0].Artifacts[0].Sha1, GenerateBuildInfo("benchmark-build-tests", "2", 1, 1, 0).Modules[0].Artifacts[0].Sha1,
+		"Expected the checksums to be unique per build")
+}
+
+func TestBuildInfoOperations(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+
+	_, err := PublishBuildInfo(GenerateBuildInfo("benchmark-build-tests", "1", 2, 3, 3), servicesManager)
+	assert.NoError(t, err)
+	_, modules, err := GetBuildInfo("benchmark-build-tests", "1", servicesManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, modules)
+
+	assert.NoError(t, DeleteBuild("benchmark-build-tests", servicesManager))
+	assert.Empty(t, fake.builds)
+	_, _, err = GetBuildInfo("benchmark-build-tests", "1", servicesManager)
+	assert.Error(t, err, "Expected an error when getting a build that doesn't exist")
+}
+
+func TestMeasureBuildInfoOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+
+	st := &BenchmarkConfig{Iterations: 3, BuildName: "benchmark-build-tests", Modules: 2, ArtifactsPerModule: 3, DependenciesPerModule: 4}
+	var results []BenchmarkResult
+	assert.NoError(t, MeasureBuildInfoOperationTimes(st, servicesManager, &results))
+	assert.Len(t, results, 6)
+	assert.Len(t, fake.builds, 3)
+	for i, result := range results {
+		if i%2 == 0 {
+			assert.Equal(t, "publish-build", result.Operation)
+		} else {
+			assert.Equal(t, "get-build", result.Operation)
+		}
+		assert.Equal(t, "14", result.Size)
+	}
+	assert.Equal(t, "benchmark-build-tests/3", results[5].FileName)
+}
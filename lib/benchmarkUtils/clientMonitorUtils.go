@@ -0,0 +1,270 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	clientMonitorInterval = time.Second
+	// The client is considered saturated when it uses this percentage of its CPUs or of the speed of its network links.
+	clientSaturationPercent = 90
+)
+
+// The resources used by the client during an interval of the run. The CPU is the percentage of all the CPUs of the
+// client used by the process, the memory is the memory obtained by the process from the OS, and the network throughput
+// is of all the network interfaces of the client except the loopback one, it is 0 when it isn't known.
+type ClientResourcesSample struct {
+	Time                       time.Time
+	CpuPercent                 float64
+	MemoryBytes                uint64
+	Goroutines                 int
+	NetworkReceiveBytesPerSec  float64
+	NetworkTransmitBytesPerSec float64
+}
+
+// The summary of the resources used by the client during a run, with a warning for every resource the client was
+// saturated on, in which case the results may be limited by the client rather than by Artifactory. The samples are
+// written to the SamplesFile next to the results file.
+type ClientHealth struct {
+	Samples                        []ClientResourcesSample `json:"-"`
+	SamplesFile                    string                  `json:"samplesFile"`
+	AvgCpuPercent                  float64                 `json:"avgCpuPercent"`
+	PeakCpuPercent                 float64                 `json:"peakCpuPercent"`
+	PeakMemoryBytes                uint64                  `json:"peakMemoryBytes"`
+	PeakGoroutines                 int                     `json:"peakGoroutines"`
+	PeakNetworkReceiveBytesPerSec  float64                 `json:"peakNetworkReceiveBytesPerSec"`
+	PeakNetworkTransmitBytesPerSec float64                 `json:"peakNetworkTransmitBytesPerSec"`
+	// The total speed of the network links of the client, 0 when it isn't known.
+	NetworkSpeedBytesPerSec float64  `json:"networkSpeedBytesPerSec,omitempty"`
+	Warnings                []string `json:"warnings,omitempty"`
+}
+
+func NewClientHealth(samples []ClientResourcesSample, networkSpeedBytesPerSec float64) *ClientHealth {
+	health := &ClientHealth{Samples: samples, NetworkSpeedBytesPerSec: networkSpeedBytesPerSec}
+	if len(samples) == 0 {
+		return health
+	}
+	var totalCpuPercent float64
+	for _, sample := range samples {
+		totalCpuPercent += sample.CpuPercent
+		if sample.CpuPercent > health.PeakCpuPercent {
+			health.PeakCpuPercent = sample.CpuPercent
+		}
+		if sample.MemoryBytes > health.PeakMemoryBytes {
+			health.PeakMemoryBytes = sample.MemoryBytes
+		}
+		if sample.Goroutines > health.PeakGoroutines {
+			health.PeakGoroutines = sample.Goroutines
+		}
+		if sample.NetworkReceiveBytesPerSec > health.PeakNetworkReceiveBytesPerSec {
+			health.PeakNetworkReceiveBytesPerSec = sample.NetworkReceiveBytesPerSec
+		}
+		if sample.NetworkTransmitBytesPerSec > health.PeakNetworkTransmitBytesPerSec {
+			health.PeakNetworkTransmitBytesPerSec = sample.NetworkTransmitBytesPerSec
+		}
+	}
+	health.AvgCpuPercent = totalCpuPercent / float64(len(samples))
+	if health.PeakCpuPercent >= clientSaturationPercent {
+		health.Warnings = append(health.Warnings, fmt.Sprintf("The client CPUs were saturated, the process used up to %.1f%% of them", health.PeakCpuPercent))
+	}
+	if networkSpeedBytesPerSec > 0 {
+		peakNetworkPercent := 100 * maxFloat(health.PeakNetworkReceiveBytesPerSec, health.PeakNetworkTransmitBytesPerSec) / networkSpeedBytesPerSec
+		if peakNetworkPercent >= clientSaturationPercent {
+			health.Warnings = append(health.Warnings, fmt.Sprintf("The client network was saturated, up to %.1f%% of the speed of its links was used", peakNetworkPercent))
+		}
+	}
+	return health
+}
+
+func (health *ClientHealth) Saturated() bool {
+	return len(health.Warnings) > 0
+}
+
+func maxFloat(first float64, second float64) float64 {
+	if first > second {
+		return first
+	}
+	return second
+}
+
+// Samples the resources used by the client every interval while running, and once when stopped.
+type clientMonitor struct {
+	samples []ClientResourcesSample
+	// The times and counters of the previous sample, the CPU usage and the network throughput are measured between
+	// consecutive samples. A counter that fails is logged once and left out of the samples.
+	lastTime                time.Time
+	lastCpuTime             time.Duration
+	cpuErr                  error
+	lastReceivedBytes       uint64
+	lastTransmittedBytes    uint64
+	networkErr              error
+	networkSpeedBytesPerSec float64
+	stopped                 chan struct{}
+	done                    chan struct{}
+}
+
+func startClientMonitor(interval time.Duration) *clientMonitor {
+	monitor := &clientMonitor{stopped: make(chan struct{}), done: make(chan struct{}), lastTime: time.Now()}
+	monitor.lastCpuTime, monitor.cpuErr = getProcessCpuTime()
+	if monitor.cpuErr != nil {
+		log.Warn("Failed to get the CPU usage of the client, it is excluded from the client health - " + monitor.cpuErr.Error())
+	}
+	monitor.lastReceivedBytes, monitor.lastTransmittedBytes, monitor.networkErr = readNetworkCounters()
+	if monitor.networkErr != nil {
+		log.Debug("Failed to get the network throughput of the client, it is excluded from the client health - " + monitor.networkErr.Error())
+	} else {
+		monitor.networkSpeedBytesPerSec = readNetworkSpeed()
+	}
+	go monitor.run(interval)
+	return monitor
+}
+
+func (monitor *clientMonitor) run(interval time.Duration) {
+	defer close(monitor.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			monitor.sample()
+		case <-monitor.stopped:
+			return
+		}
+	}
+}
+
+func (monitor *clientMonitor) stop() *ClientHealth {
+	close(monitor.stopped)
+	<-monitor.done
+	monitor.sample()
+	return NewClientHealth(monitor.samples, monitor.networkSpeedBytesPerSec)
+}
+
+func (monitor *clientMonitor) sample() {
+	now := time.Now()
+	elapsed := now.Sub(monitor.lastTime).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	sample := ClientResourcesSample{Time: now, MemoryBytes: memStats.Sys, Goroutines: runtime.NumGoroutine()}
+	if monitor.cpuErr == nil {
+		var cpuTime time.Duration
+		cpuTime, monitor.cpuErr = getProcessCpuTime()
+		if monitor.cpuErr == nil {
+			sample.CpuPercent = 100 * (cpuTime - monitor.lastCpuTime).Seconds() / elapsed / float64(runtime.NumCPU())
+			monitor.lastCpuTime = cpuTime
+		}
+	}
+	if monitor.networkErr == nil {
+		var receivedBytes, transmittedBytes uint64
+		receivedBytes, transmittedBytes, monitor.networkErr = readNetworkCounters()
+		// The counters of an interface that was removed during the interval are missing, so the interval is skipped.
+		if monitor.networkErr == nil && receivedBytes >= monitor.lastReceivedBytes && transmittedBytes >= monitor.lastTransmittedBytes {
+			sample.NetworkReceiveBytesPerSec = float64(receivedBytes-monitor.lastReceivedBytes) / elapsed
+			sample.NetworkTransmitBytesPerSec = float64(transmittedBytes-monitor.lastTransmittedBytes) / elapsed
+		}
+		monitor.lastReceivedBytes, monitor.lastTransmittedBytes = receivedBytes, transmittedBytes
+	}
+	monitor.lastTime = now
+	monitor.samples = append(monitor.samples, sample)
+}
+
+// Returns the bytes received and transmitted by all the network interfaces except the loopback one, which are only
+// available on Linux.
+func readNetworkCounters() (uint64, uint64, error) {
+	data, err := ioutil.ReadFile("/proc/net/dev")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseNetworkCounters(string(data))
+}
+
+// Parses the /proc/net/dev format, where every interface has a line such as
+// "eth0: <received bytes> <7 more received counters> <transmitted bytes> <7 more transmitted counters>".
+func parseNetworkCounters(data string) (uint64, uint64, error) {
+	var receivedBytes, transmittedBytes uint64
+	for _, line := range strings.Split(data, "\n") {
+		separator := strings.Index(line, ":")
+		if separator < 0 || strings.TrimSpace(line[:separator]) == "lo" {
+			continue
+		}
+		fields := strings.Fields(line[separator+1:])
+		if len(fields) < 9 {
+			return 0, 0, errors.New("Unexpected network counters of interface [" + strings.TrimSpace(line[:separator]) + "]")
+		}
+		received, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		transmitted, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		receivedBytes += received
+		transmittedBytes += transmitted
+	}
+	return receivedBytes, transmittedBytes, nil
+}
+
+// Returns the total speed of the network links of the client, which is only available on Linux for physical interfaces.
+// Returns 0 when it isn't known.
+func readNetworkSpeed() float64 {
+	interfaces, err := ioutil.ReadDir("/sys/class/net")
+	if err != nil {
+		return 0
+	}
+	var speedBytesPerSec float64
+	for _, networkInterface := range interfaces {
+		if networkInterface.Name() == "lo" {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join("/sys/class/net", networkInterface.Name(), "speed"))
+		if err != nil {
+			continue
+		}
+		// The speed is in megabits per second, and is -1 for links without a known speed.
+		speedInMbps, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err == nil && speedInMbps > 0 {
+			speedBytesPerSec += float64(speedInMbps) * 1000 * 1000 / 8
+		}
+	}
+	return speedBytesPerSec
+}
+
+// Returns the path of the client resources file of the results file, results.csv has the results-client-resources.csv
+// file.
+func GetClientResourcesFilePath(resultsFilePath string) string {
+	return strings.TrimSuffix(resultsFilePath, filepath.Ext(resultsFilePath)) + "-client-resources.csv"
+}
+
+// Writes every sample as a row, with the seconds elapsed since the first sample.
+func WriteClientResourcesSamples(filePath string, samples []ClientResourcesSample) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return errors.New("Failed to write the client resources file [" + filePath + "] - " + err.Error())
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	fmt.Fprintln(writer, "time,elapsed (sec),cpu (%),memory (MB),goroutines,network received (MB/sec),network sent (MB/sec)")
+	for _, sample := range samples {
+		fmt.Fprintf(writer, "%s,%.3f,%.1f,%.1f,%d,%.2f,%.2f\n", sample.Time.Format(time.RFC3339Nano), sample.Time.Sub(samples[0].Time).Seconds(),
+			sample.CpuPercent, float64(sample.MemoryBytes)/1024/1024, sample.Goroutines, sample.NetworkReceiveBytesPerSec/1024/1024,
+			sample.NetworkTransmitBytesPerSec/1024/1024)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package benchmarkUtils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetworkCounters(t *testing.T) {
+	data := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo: 5000      10    0    0    0     0          0         0     5000      10    0    0    0     0       0          0
+  eth0: 1200      20    0    0    0     0          0         0      300       4    0    0    0     0       0          0
+  eth1:   34       1    0    0    0     0          0         0       70       1    0    0    0     0       0          0
+`
+	received, transmitted, err := parseNetworkCounters(data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), received)
+	assert.Equal(t, uint64(370), transmitted)
+
+	_, _, err = parseNetworkCounters("eth0: 1200 20\n")
+	assert.EqualError(t, err, "Unexpected network counters of interface [eth0]")
+}
+
+func TestNewClientHealth(t *testing.T) {
+	start := time.Now()
+	samples := []ClientResourcesSample{
+		{Time: start, CpuPercent: 40, MemoryBytes: 100, Goroutines: 10, NetworkReceiveBytesPerSec: 1000, NetworkTransmitBytesPerSec: 10},
+		{Time: start.Add(time.Second), CpuPercent: 95, MemoryBytes: 300, Goroutines: 30, NetworkReceiveBytesPerSec: 9500, NetworkTransmitBytesPerSec: 20},
+		{Time: start.Add(2 * time.Second), CpuPercent: 60, MemoryBytes: 200, Goroutines: 20, NetworkReceiveBytesPerSec: 500, NetworkTransmitBytesPerSec: 30},
+	}
+	health := NewClientHealth(samples, 10000)
+	assert.Equal(t, 65.0, health.AvgCpuPercent)
+	assert.Equal(t, 95.0, health.PeakCpuPercent)
+	assert.Equal(t, uint64(300), health.PeakMemoryBytes)
+	assert.Equal(t, 30, health.PeakGoroutines)
+	assert.Equal(t, 9500.0, health.PeakNetworkReceiveBytesPerSec)
+	assert.Equal(t, 30.0, health.PeakNetworkTransmitBytesPerSec)
+	assert.True(t, health.Saturated())
+	assert.Equal(t, []string{
+		"The client CPUs were saturated, the process used up to 95.0% of them",
+		"The client network was saturated, up to 95.0% of the speed of its links was used",
+	}, health.Warnings)
+
+	// The network isn't checked when the speed of the links isn't known.
+	samples[1].CpuPercent = 80
+	health = NewClientHealth(samples, 0)
+	assert.False(t, health.Saturated())
+	assert.False(t, NewClientHealth(nil, 0).Saturated())
+}
+
+func TestWriteClientResourcesSamples(t *testing.T) {
+	filePath := "client-resources.csv"
+	defer os.Remove(filePath)
+	start := time.Date(2023, 2, 21, 11, 30, 0, 0, time.UTC)
+	samples := []ClientResourcesSample{
+		{Time: start, CpuPercent: 12.34, MemoryBytes: 10 * 1024 * 1024, Goroutines: 8, NetworkReceiveBytesPerSec: 1024 * 1024},
+		{Time: start.Add(1500 * time.Millisecond), CpuPercent: 50, MemoryBytes: 20 * 1024 * 1024, Goroutines: 12,
+			NetworkTransmitBytesPerSec: 512 * 1024},
+	}
+	assert.NoError(t, WriteClientResourcesSamples(filePath, samples))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "time,elapsed (sec),cpu (%),memory (MB),goroutines,network received (MB/sec),network sent (MB/sec)\n"+
+		"2023-02-21T11:30:00Z,0.000,12.3,10.0,8,1.00,0.00\n"+
+		"2023-02-21T11:30:01.5Z,1.500,50.0,20.0,12,0.00,0.50\n", string(content))
+}
+
+func TestMonitorClientResources(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	resultsFilePath := "client-health-results.csv"
+	defer os.Remove(resultsFilePath)
+	defer os.Remove(GetMetadataFilePath(resultsFilePath))
+	defer os.Remove(GetClientResourcesFilePath(resultsFilePath))
+
+	metadata := NewResultsMetadata(&BenchmarkConfig{Operation: DownloadOperation}, servicesManager)
+	metadata.clientMonitor = startClientMonitor(10 * time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	results := []BenchmarkResult{{"file1.dat", "1", "1s", "1.00", "download"}}
+	assert.NoError(t, WriteOperationResults(resultsFilePath, metadata, results))
+	assert.NoError(t, WriteResultsMetadata(resultsFilePath, metadata))
+
+	if assert.NotNil(t, metadata.ClientHealth) {
+		assert.GreaterOrEqual(t, len(metadata.ClientHealth.Samples), 2)
+		assert.Greater(t, metadata.ClientHealth.PeakMemoryBytes, uint64(0))
+		assert.Greater(t, metadata.ClientHealth.PeakGoroutines, 0)
+		assert.GreaterOrEqual(t, metadata.ClientHealth.AvgCpuPercent, 0.0)
+	}
+	content, err := ioutil.ReadFile(resultsFilePath)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "# client health: avg cpu "))
+	assert.FileExists(t, GetClientResourcesFilePath(resultsFilePath))
+
+	data, err := ioutil.ReadFile(GetMetadataFilePath(resultsFilePath))
+	assert.NoError(t, err)
+	var written struct {
+		ClientHealth ClientHealth `json:"clientHealth"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, "client-health-results-client-resources.csv", written.ClientHealth.SamplesFile)
+	assert.Greater(t, written.ClientHealth.PeakGoroutines, 0)
+}
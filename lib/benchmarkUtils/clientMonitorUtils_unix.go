@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package benchmarkUtils
+
+import (
+	"syscall"
+	"time"
+)
+
+// Returns the user and system CPU time used by the process so far.
+func getProcessCpuTime() (time.Duration, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, err
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
@@ -0,0 +1,20 @@
+package benchmarkUtils
+
+import (
+	"syscall"
+	"time"
+)
+
+// Returns the user and kernel CPU time used by the process so far.
+func getProcessCpuTime() (time.Duration, error) {
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0, err
+	}
+	var creation, exit, kernel, user syscall.Filetime
+	if err = syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return 0, err
+	}
+	// The times are in units of 100 nanoseconds.
+	return time.Duration((int64(kernel.HighDateTime)<<32 + int64(kernel.LowDateTime) + int64(user.HighDateTime)<<32 + int64(user.LowDateTime)) * 100), nil
+}
@@ -0,0 +1,349 @@
+package benchmarkUtils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+)
+
+// The operation measured by a command, it is used in the name of the results file of the command.
+type Operation string
+
+const (
+	UploadOperation        Operation = "upload"
+	UploadExplodeOperation Operation = "upload-explode"
+	DownloadOperation      Operation = "download"
+	MixedOperation         Operation = "mixed"
+	DeleteOperation        Operation = "delete"
+	SearchOperation        Operation = "search"
+	PropsOperation         Operation = "props"
+	CopyOperation          Operation = "copy"
+	MoveOperation          Operation = "move"
+	BuildOperation         Operation = "build"
+	TreeOperation          Operation = "tree"
+	RangeOperation         Operation = "range"
+	DockerOperation        Operation = "docker"
+	ScenarioOperation      Operation = "scenario"
+)
+
+// The settings of a benchmark, each command uses the settings of its own flags. The zero value of an optional setting,
+// such as the rate, the ramp, the bandwidth limit and the warm-up, disables it. The password isn't part of the JSON form
+// of the config, which is recorded in the metadata of the results.
+type BenchmarkConfig struct {
+	Operation                 Operation `json:"operation,omitempty"`
+	FilesSizesInMb            int       `json:"filesSizesInMb,omitempty"`
+	Iterations                int       `json:"iterations,omitempty"`
+	RepositoryName            string    `json:"repositoryName,omitempty"`
+	Url                       string    `json:"url,omitempty"`
+	UserName                  string    `json:"userName,omitempty"`
+	Password                  string    `json:"-"`
+	ServerId                  string    `json:"serverId,omitempty"`
+	Append                    string    `json:"append,omitempty"`
+	SameFile                  bool      `json:"sameFile,omitempty"`
+	ReadRatio                 int       `json:"readRatio,omitempty"`
+	Concurrency               int       `json:"concurrency,omitempty"`
+	AqlQueries                string    `json:"aqlQueries,omitempty"`
+	SearchPatterns            string    `json:"searchPatterns,omitempty"`
+	SearchProps               string    `json:"searchProps,omitempty"`
+	Repeats                   int       `json:"repeats,omitempty"`
+	PropsCount                int       `json:"propsCount,omitempty"`
+	DestinationRepositoryName string    `json:"destinationRepositoryName,omitempty"`
+	BuildName                 string    `json:"buildName,omitempty"`
+	Modules                   int       `json:"modules,omitempty"`
+	ArtifactsPerModule        int       `json:"artifactsPerModule,omitempty"`
+	DependenciesPerModule     int       `json:"dependenciesPerModule,omitempty"`
+	Explode                   bool      `json:"explode,omitempty"`
+	TreeDepth                 int       `json:"treeDepth,omitempty"`
+	TreeFanOut                int       `json:"treeFanOut,omitempty"`
+	TreeFiles                 int       `json:"treeFiles,omitempty"`
+	FileSizeInKb              int       `json:"fileSizeInKb,omitempty"`
+	Threads                   int       `json:"threads,omitempty"`
+	// A split count of 0 disables downloading a file in concurrent parts, the minimal split size is in KB.
+	SplitCount   int    `json:"splitCount,omitempty"`
+	MinSplitSize int64  `json:"minSplitSize,omitempty"`
+	RangeSize    int    `json:"rangeSize,omitempty"`
+	RangePattern string `json:"rangePattern,omitempty"`
+	Layers       int    `json:"layers,omitempty"`
+	ImageName    string `json:"imageName,omitempty"`
+	// The number of operations per second.
+	Rate         float64       `json:"rate,omitempty"`
+	Ramp         []int         `json:"ramp,omitempty"`
+	StepDuration time.Duration `json:"stepDuration,omitempty"`
+	// The number of bytes per second.
+	BandwidthLimit float64 `json:"bandwidthLimit,omitempty"`
+	BandwidthScope string  `json:"bandwidthScope,omitempty"`
+	Warmup         int     `json:"warmup,omitempty"`
+	// The interval of collecting the server metrics, 0 disables collecting them.
+	ServerMetricsInterval time.Duration `json:"serverMetricsInterval,omitempty"`
+	// The address the live metrics are served on, such as :9100, empty disables serving them.
+	MetricsListen string `json:"metricsListen,omitempty"`
+	// The Pushgateway and InfluxDB the results are pushed to when the run ends, empty disables pushing to them.
+	PushGatewayUrl string            `json:"pushGatewayUrl,omitempty"`
+	InfluxDbUrl    string            `json:"influxDbUrl,omitempty"`
+	InfluxDbToken  string            `json:"-"`
+	PushJob        string            `json:"pushJob,omitempty"`
+	PushTags       map[string]string `json:"pushTags,omitempty"`
+	PushSamples    bool              `json:"pushSamples,omitempty"`
+}
+
+// Returns the config of the operation with the defaults of its settings, which are also the default values of the flags
+// of its command. The settings that are disabled by their zero value have no default.
+func NewBenchmarkConfig(operation Operation) *BenchmarkConfig {
+	defaultParams := services.NewDownloadParams()
+	config := &BenchmarkConfig{
+		Operation:      operation,
+		SplitCount:     defaultParams.SplitCount,
+		MinSplitSize:   defaultParams.MinSplitSize,
+		RangePattern:   SequentialRanges,
+		BandwidthScope: WorkerBandwidthScope,
+	}
+	switch operation {
+	case UploadOperation, UploadExplodeOperation, DownloadOperation:
+		config.FilesSizesInMb = 50
+		config.Iterations = 30
+		config.RepositoryName = "benchmark-up-tests"
+		if operation == DownloadOperation {
+			config.RepositoryName = "benchmark-dl-tests"
+		}
+		config.Concurrency = 10
+		config.StepDuration = 30 * time.Second
+		config.PushJob = DefaultPushJob
+	case MixedOperation:
+		config.FilesSizesInMb = 50
+		config.Iterations = 30
+		config.RepositoryName = "benchmark-mixed-tests"
+		config.ReadRatio = 80
+		config.Concurrency = 4
+	case DeleteOperation:
+		config.FilesSizesInMb = 1
+		config.Iterations = 30
+		config.RepositoryName = "benchmark-del-tests"
+	case SearchOperation:
+		config.FilesSizesInMb = 1
+		config.Iterations = 100
+		config.RepositoryName = "benchmark-search-tests"
+		config.AqlQueries = `items.find({"repo":"{repo}"})|items.find({"repo":"{repo}","@benchmark.group":"1"})`
+		config.SearchPatterns = "{repo}/*|{repo}/File1*"
+		config.SearchProps = "benchmark.group=1|benchmark.index=1"
+		config.Repeats = 10
+	case PropsOperation:
+		config.FilesSizesInMb = 1
+		config.Iterations = 30
+		config.RepositoryName = "benchmark-props-tests"
+		config.PropsCount = 10
+		config.Concurrency = 4
+	case CopyOperation, MoveOperation:
+		config.FilesSizesInMb = 1
+		config.Iterations = 30
+		config.RepositoryName = "benchmark-" + string(operation) + "-tests"
+		config.DestinationRepositoryName = "benchmark-" + string(operation) + "-dest-tests"
+	case BuildOperation:
+		config.Iterations = 10
+		config.BuildName = "benchmark-build-tests"
+		config.Modules = 10
+		config.ArtifactsPerModule = 100
+		config.DependenciesPerModule = 100
+	case TreeOperation:
+		config.RepositoryName = "benchmark-tree-tests"
+		config.TreeFiles = 1000
+		config.TreeDepth = 3
+		config.TreeFanOut = 3
+		config.FileSizeInKb = 1
+		config.Threads = 3
+	case RangeOperation:
+		config.FilesSizesInMb = 50
+		config.Iterations = 5
+		config.RepositoryName = "benchmark-range-tests"
+		config.RangeSize = 1024
+		config.Repeats = 10
+	case DockerOperation:
+		config.Iterations = 5
+		config.RepositoryName = "benchmark-docker-tests"
+		config.Layers = 3
+		config.FileSizeInKb = 10240
+		config.ImageName = "benchmark-image"
+	}
+	return config
+}
+
+// Validates the settings of the operation of the config, and the optional settings shared by the operations, which are
+// valid when they are disabled. All the commands and the phases of the scenarios are validated by it.
+func (cliConfig *BenchmarkConfig) Validate() error {
+	var validators []func(*BenchmarkConfig) error
+	switch cliConfig.Operation {
+	case UploadOperation, UploadExplodeOperation:
+		validators = append(validators, ValidateInput, ValidateUploadInput)
+	case DownloadOperation:
+		validators = append(validators, ValidateInput, ValidateSplitInput)
+	case MixedOperation:
+		validators = append(validators, ValidateInput, ValidateMixedInput)
+	case DeleteOperation:
+		validators = append(validators, ValidateInput)
+	case SearchOperation:
+		validators = append(validators, ValidateInput, ValidateSearchInput)
+	case PropsOperation:
+		validators = append(validators, ValidateInput, ValidatePropsInput)
+	case CopyOperation, MoveOperation:
+		validators = append(validators, ValidateInput, ValidateMoveCopyInput)
+	case BuildOperation:
+		validators = append(validators, ValidateBuildInput)
+	case TreeOperation:
+		validators = append(validators, ValidateTreeInput)
+	case RangeOperation:
+		validators = append(validators, ValidateInput, ValidateRangeInput)
+	case DockerOperation:
+		validators = append(validators, ValidateDockerInput)
+	case ScenarioOperation:
+		// The server and the repository of a scenario are validated along with its phases when it is loaded.
+	default:
+		return errors.New("Unknown operation [" + string(cliConfig.Operation) + "]")
+	}
+	validators = append(validators, ValidateBandwidthInput, ValidateWarmupInput, ValidateServerMetricsInput, ValidateMetricsListenInput,
+		ValidatePushInput, ValidateRateInput, ValidateRampInput)
+	for _, validate := range validators {
+		if err := validate(cliConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The raw values of the settings keyed by their names, such as the flags of a command.
+type ConfigValues interface {
+	GetStringFlagValue(name string) string
+	GetBoolFlagValue(name string) bool
+}
+
+// Reads the raw values of the settings into a config. An empty value keeps the current value of the setting, and the
+// first value that can't be parsed is kept as the error of the reader, so a whole config is read before checking it.
+type ConfigReader struct {
+	values ConfigValues
+	err    error
+}
+
+func NewConfigReader(values ConfigValues) *ConfigReader {
+	return &ConfigReader{values: values}
+}
+
+func (reader *ConfigReader) Err() error {
+	return reader.err
+}
+
+func (reader *ConfigReader) ReadString(name string, target *string) {
+	if value := reader.values.GetStringFlagValue(name); value != "" {
+		*target = value
+	}
+}
+
+// The values of bool flags which can fail to parse, such as the ones set by environment variables.
+type boolFlagParser interface {
+	ParseBoolFlagValue(name string) (bool, error)
+}
+
+func (reader *ConfigReader) ReadBool(name string, target *bool) {
+	parser, ok := reader.values.(boolFlagParser)
+	if !ok {
+		*target = reader.values.GetBoolFlagValue(name)
+		return
+	}
+	value, err := parser.ParseBoolFlagValue(name)
+	if err != nil {
+		if reader.err == nil {
+			reader.err = err
+		}
+		return
+	}
+	*target = value
+}
+
+func (reader *ConfigReader) ReadInt(name string, target *int) {
+	reader.read(name, func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("The value [" + value + "] of " + name + " must be an integer")
+		}
+		*target = parsed
+		return nil
+	})
+}
+
+func (reader *ConfigReader) ReadInt64(name string, target *int64) {
+	reader.read(name, func(value string) error {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.New("The value [" + value + "] of " + name + " must be an integer")
+		}
+		*target = parsed
+		return nil
+	})
+}
+
+func (reader *ConfigReader) ReadFloat(name string, target *float64) {
+	reader.read(name, func(value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("The value [" + value + "] of " + name + " must be a number")
+		}
+		*target = parsed
+		return nil
+	})
+}
+
+// Reads a whole number of seconds, such as 30.
+func (reader *ConfigReader) ReadSeconds(name string, target *time.Duration) {
+	reader.read(name, func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("The value [" + value + "] of " + name + " must be a whole number of seconds")
+		}
+		*target = time.Duration(parsed) * time.Second
+		return nil
+	})
+}
+
+// Reads comma separated concurrency levels, such as 1,2,4,8.
+func (reader *ConfigReader) ReadRamp(name string, target *[]int) {
+	reader.read(name, func(value string) error {
+		levels, err := ParseRampLevels(value)
+		if err != nil {
+			return err
+		}
+		*target = levels
+		return nil
+	})
+}
+
+// Reads a bandwidth in bytes per second, such as 10MB/s.
+func (reader *ConfigReader) ReadBandwidth(name string, target *float64) {
+	reader.read(name, func(value string) error {
+		bytesPerSecond, err := ParseBandwidth(value)
+		if err != nil {
+			return err
+		}
+		*target = bytesPerSecond
+		return nil
+	})
+}
+
+// Reads comma separated name=value tags, such as env=ci,branch=main.
+func (reader *ConfigReader) ReadTags(name string, target *map[string]string) {
+	reader.read(name, func(value string) error {
+		tags, err := ParsePushTags(value)
+		if err != nil {
+			return err
+		}
+		*target = tags
+		return nil
+	})
+}
+
+func (reader *ConfigReader) read(name string, parse func(value string) error) {
+	value := strings.TrimSpace(reader.values.GetStringFlagValue(name))
+	if value == "" || reader.err != nil {
+		return
+	}
+	reader.err = parse(value)
+}
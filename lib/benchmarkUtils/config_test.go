@@ -0,0 +1,117 @@
+package benchmarkUtils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigValues map[string]string
+
+func (values testConfigValues) GetStringFlagValue(name string) string {
+	return values[name]
+}
+
+func (values testConfigValues) GetBoolFlagValue(name string) bool {
+	return values[name] == "true"
+}
+
+func TestNewBenchmarkConfig(t *testing.T) {
+	config := NewBenchmarkConfig(DownloadOperation)
+	assert.Equal(t, DownloadOperation, config.Operation)
+	assert.Equal(t, 3, config.SplitCount)
+	assert.Equal(t, int64(5120), config.MinSplitSize)
+	assert.Equal(t, SequentialRanges, config.RangePattern)
+	assert.Equal(t, WorkerBandwidthScope, config.BandwidthScope)
+	assert.Equal(t, "benchmark-dl-tests", config.RepositoryName)
+	assert.Equal(t, 30*time.Second, config.StepDuration)
+	assert.Equal(t, DefaultPushJob, config.PushJob)
+
+	config = NewBenchmarkConfig(MoveOperation)
+	assert.Equal(t, "benchmark-move-tests", config.RepositoryName)
+	assert.Equal(t, "benchmark-move-dest-tests", config.DestinationRepositoryName)
+}
+
+func TestBenchmarkConfigValidate(t *testing.T) {
+	// The defaults of every command are valid.
+	for _, operation := range []Operation{UploadOperation, UploadExplodeOperation, DownloadOperation, MixedOperation, DeleteOperation,
+		SearchOperation, PropsOperation, CopyOperation, MoveOperation, BuildOperation, TreeOperation, RangeOperation, DockerOperation,
+		ScenarioOperation} {
+		assert.NoError(t, NewBenchmarkConfig(operation).Validate(), string(operation))
+	}
+
+	config := NewBenchmarkConfig(DownloadOperation)
+	config.SplitCount = -1
+	assert.EqualError(t, config.Validate(), "Split count and minimal split size must be zero or positive")
+	config = NewBenchmarkConfig(MixedOperation)
+	config.Warmup = -1
+	assert.EqualError(t, config.Validate(), "Warmup must be a non-negative number of operations")
+	config = NewBenchmarkConfig(UploadOperation)
+	config.Iterations = 0
+	assert.EqualError(t, config.Validate(), "iterations must be positive")
+	config = NewBenchmarkConfig(TreeOperation)
+	config.TreeFanOut = 0
+	assert.EqualError(t, config.Validate(), "fan_out must be positive")
+	config = NewBenchmarkConfig(DockerOperation)
+	config.FileSizeInKb = 0
+	assert.EqualError(t, config.Validate(), "layer_size must be positive")
+	assert.EqualError(t, NewBenchmarkConfig("copy-all").Validate(), "Unknown operation [copy-all]")
+}
+
+func TestConfigReader(t *testing.T) {
+	config := NewBenchmarkConfig(DownloadOperation)
+	reader := NewConfigReader(testConfigValues{
+		"repo_name":      "benchmark-dl-tests",
+		"same_file":      "true",
+		"iterations":     " 10 ",
+		"min_split_size": "1024",
+		"rate":           "0.5",
+		"step_duration":  "30",
+		"ramp":           "1,2,4",
+		"bandwidth":      "10MB/s",
+	})
+	reader.ReadString("repo_name", &config.RepositoryName)
+	reader.ReadString("url", &config.Url)
+	reader.ReadBool("same_file", &config.SameFile)
+	reader.ReadInt("iterations", &config.Iterations)
+	reader.ReadInt("split_count", &config.SplitCount)
+	reader.ReadInt64("min_split_size", &config.MinSplitSize)
+	reader.ReadFloat("rate", &config.Rate)
+	reader.ReadSeconds("step_duration", &config.StepDuration)
+	reader.ReadRamp("ramp", &config.Ramp)
+	reader.ReadBandwidth("bandwidth", &config.BandwidthLimit)
+	assert.NoError(t, reader.Err())
+
+	assert.Equal(t, "benchmark-dl-tests", config.RepositoryName)
+	assert.Empty(t, config.Url)
+	assert.True(t, config.SameFile)
+	assert.Equal(t, 10, config.Iterations)
+	// An empty value keeps the default.
+	assert.Equal(t, 3, config.SplitCount)
+	assert.Equal(t, int64(1024), config.MinSplitSize)
+	assert.Equal(t, 0.5, config.Rate)
+	assert.Equal(t, 30*time.Second, config.StepDuration)
+	assert.Equal(t, []int{1, 2, 4}, config.Ramp)
+	assert.Equal(t, float64(10*1024*1024), config.BandwidthLimit)
+}
+
+func TestConfigReaderErrors(t *testing.T) {
+	config := NewBenchmarkConfig(UploadOperation)
+	reader := NewConfigReader(testConfigValues{"size": "large", "iterations": "ten"})
+	reader.ReadInt("size", &config.FilesSizesInMb)
+	reader.ReadInt("iterations", &config.Iterations)
+	// The first error is kept.
+	assert.EqualError(t, reader.Err(), "The value [large] of size must be an integer")
+
+	for name, read := range map[string]func(reader *ConfigReader){
+		"rate":          func(reader *ConfigReader) { reader.ReadFloat("rate", &config.Rate) },
+		"step_duration": func(reader *ConfigReader) { reader.ReadSeconds("step_duration", &config.StepDuration) },
+		"ramp":          func(reader *ConfigReader) { reader.ReadRamp("ramp", &config.Ramp) },
+		"bandwidth":     func(reader *ConfigReader) { reader.ReadBandwidth("bandwidth", &config.BandwidthLimit) },
+	} {
+		reader = NewConfigReader(testConfigValues{name: "fast"})
+		read(reader)
+		assert.Error(t, reader.Err(), name)
+	}
+}
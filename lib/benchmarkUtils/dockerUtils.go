@@ -0,0 +1,259 @@
+package benchmarkUtils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerConfigMediaType   = "application/vnd.docker.container.image.v1+json"
+	dockerLayerMediaType    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// A blob of a Docker image, either a layer or the image config.
+type DockerBlob struct {
+	MediaType string `json:"mediaType"`
+	Size      int    `json:"size"`
+	Digest    string `json:"digest"`
+	data      []byte
+}
+
+// A Docker image synthesized in-process, with the manifest that references its config and layers.
+type DockerImage struct {
+	Name     string
+	Tag      string
+	Config   DockerBlob
+	Layers   []DockerBlob
+	Manifest []byte
+}
+
+type dockerManifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        DockerBlob   `json:"config"`
+	Layers        []DockerBlob `json:"layers"`
+}
+
+func newDockerBlob(mediaType string, data []byte) DockerBlob {
+	return DockerBlob{MediaType: mediaType, Size: len(data), Digest: fmt.Sprintf("sha256:%x", sha256.Sum256(data)), data: data}
+}
+
+// Generates an image with the given number of layers of random content, each of them of layerSizeInKb KB.
+func GenerateDockerImage(name string, tag string, layers int, layerSizeInKb int) (*DockerImage, error) {
+	image := &DockerImage{Name: name, Tag: tag}
+	var diffIds []string
+	for i := 0; i < layers; i++ {
+		data := make([]byte, layerSizeInKb*1024)
+		rand.Read(data)
+		layer := newDockerBlob(dockerLayerMediaType, data)
+		image.Layers = append(image.Layers, layer)
+		diffIds = append(diffIds, layer.Digest)
+	}
+	config, err := json.Marshal(map[string]interface{}{
+		"architecture": "amd64",
+		"os":           "linux",
+		"created":      time.Now().UTC().Format(time.RFC3339),
+		"rootfs":       map[string]interface{}{"type": "layers", "diff_ids": diffIds},
+	})
+	if err != nil {
+		return nil, err
+	}
+	image.Config = newDockerBlob(dockerConfigMediaType, config)
+	image.Manifest, err = json.Marshal(dockerManifest{SchemaVersion: 2, MediaType: dockerManifestMediaType, Config: image.Config, Layers: image.Layers})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+func CreateDockerLocalRepository(repoName string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	params := services.NewDockerLocalRepositoryParams()
+	params.Key = repoName
+	err := servicesManager.CreateLocalRepository().Docker(params)
+	if err != nil && strings.Contains(err.Error(), "Case insensitive repository key already exists") {
+		log.Info("Recreating [" + repoName + "] Because it is already exists")
+		deleteError := DeleteLocalRepository(repoName, servicesManager)
+		if deleteError != nil {
+			return deleteError
+		}
+		return servicesManager.CreateLocalRepository().Docker(params)
+	}
+	return err
+}
+
+// Returns the URL of the Docker Registry v2 API of the repository, using the repository path method of Artifactory.
+func getDockerRegistryUrl(repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) string {
+	return servicesManager.GetConfig().GetServiceDetails().GetUrl() + "api/docker/" + repositoryName + "/v2/"
+}
+
+// Pushes the blob with a monolithic upload, i.e. starts an upload session and completes it with the whole content.
+func PushDockerBlob(repositoryName string, imageName string, blob DockerBlob, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	registryUrl := getDockerRegistryUrl(repositoryName, servicesManager)
+	httpClientsDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	start := time.Now()
+	resp, body, err := servicesManager.Client().SendPost(registryUrl+imageName+"/blobs/uploads/", nil, &httpClientsDetails)
+	if err != nil {
+		return 0, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted); err != nil {
+		return 0, err
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return 0, err
+	}
+	query := location.Query()
+	query.Set("digest", blob.Digest)
+	location.RawQuery = query.Encode()
+	httpClientsDetails = servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	utils.SetContentType("application/octet-stream", &httpClientsDetails.Headers)
+	resp, body, err = servicesManager.Client().SendPut(location.String(), blob.data, &httpClientsDetails)
+	end := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated); err != nil {
+		return 0, err
+	}
+	return end, nil
+}
+
+func PushDockerManifest(repositoryName string, image *DockerImage, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	httpClientsDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	utils.SetContentType(dockerManifestMediaType, &httpClientsDetails.Headers)
+	manifestUrl := getDockerRegistryUrl(repositoryName, servicesManager) + image.Name + "/manifests/" + url.PathEscape(image.Tag)
+	start := time.Now()
+	resp, body, err := servicesManager.Client().SendPut(manifestUrl, image.Manifest, &httpClientsDetails)
+	end := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated); err != nil {
+		return 0, err
+	}
+	return end, nil
+}
+
+// Pulls the blob and verifies its digest.
+func PullDockerBlob(repositoryName string, imageName string, blob DockerBlob, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	httpClientsDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	start := time.Now()
+	resp, body, _, err := servicesManager.Client().SendGet(getDockerRegistryUrl(repositoryName, servicesManager)+imageName+"/blobs/"+blob.Digest, true, &httpClientsDetails)
+	end := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return 0, err
+	}
+	if fmt.Sprintf("sha256:%x", sha256.Sum256(body)) != blob.Digest {
+		return 0, errors.New("The content of the pulled blob [" + blob.Digest + "] of [" + imageName + "] doesn't match its digest")
+	}
+	return end, nil
+}
+
+// Pulls the manifest of the image and verifies it is the pushed manifest.
+func PullDockerManifest(repositoryName string, image *DockerImage, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+	httpClientsDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	utils.AddHeader("Accept", dockerManifestMediaType, &httpClientsDetails.Headers)
+	manifestUrl := getDockerRegistryUrl(repositoryName, servicesManager) + image.Name + "/manifests/" + url.PathEscape(image.Tag)
+	start := time.Now()
+	resp, body, _, err := servicesManager.Client().SendGet(manifestUrl, true, &httpClientsDetails)
+	end := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return 0, err
+	}
+	var pulled dockerManifest
+	if err = json.Unmarshal(body, &pulled); err != nil {
+		return 0, errors.New("Failed to parse the pulled manifest of [" + image.Name + ":" + image.Tag + "] - " + err.Error())
+	}
+	if pulled.Config.Digest != image.Config.Digest || len(pulled.Layers) != len(image.Layers) {
+		return 0, errors.New("The pulled manifest of [" + image.Name + ":" + image.Tag + "] doesn't match the pushed manifest")
+	}
+	return end, nil
+}
+
+// Generates Iterations images with the configured number and size of layers, pushes each of them and then pulls it back.
+// Every layer is measured separately ('push-layer' / 'pull-layer' operations), as well as the config blob ('push-config'
+// / 'pull-config' operations) and the manifest ('push-manifest' / 'pull-manifest' operations).
+func MeasureDockerOperationTimes(st *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	for i := 1; i <= st.Iterations; i++ {
+		image, err := GenerateDockerImage(st.ImageName, strconv.Itoa(i), st.Layers, st.FileSizeInKb)
+		if err != nil {
+			return err
+		}
+		imagePath := st.RepositoryName + "/" + image.Name + ":" + image.Tag
+		manifestSizeInKb := float64(len(image.Manifest)) / 1024
+
+		log.Info("Pushing [" + imagePath + "]")
+		for _, layer := range image.Layers {
+			err = measureDockerBlob("push-layer", imagePath, layer, benchmarkResults, func() (time.Duration, error) {
+				return PushDockerBlob(st.RepositoryName, image.Name, layer, servicesManager)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		err = measureDockerBlob("push-config", imagePath, image.Config, benchmarkResults, func() (time.Duration, error) {
+			return PushDockerBlob(st.RepositoryName, image.Name, image.Config, servicesManager)
+		})
+		if err != nil {
+			return err
+		}
+		duration, err := PushDockerManifest(st.RepositoryName, image, servicesManager)
+		if err != nil {
+			return err
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewKBBenchmarkResult("push-manifest", imagePath, manifestSizeInKb, duration))
+
+		log.Info("Pulling [" + imagePath + "]")
+		duration, err = PullDockerManifest(st.RepositoryName, image, servicesManager)
+		if err != nil {
+			return err
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewKBBenchmarkResult("pull-manifest", imagePath, manifestSizeInKb, duration))
+		err = measureDockerBlob("pull-config", imagePath, image.Config, benchmarkResults, func() (time.Duration, error) {
+			return PullDockerBlob(st.RepositoryName, image.Name, image.Config, servicesManager)
+		})
+		if err != nil {
+			return err
+		}
+		for _, layer := range image.Layers {
+			err = measureDockerBlob("pull-layer", imagePath, layer, benchmarkResults, func() (time.Duration, error) {
+				return PullDockerBlob(st.RepositoryName, image.Name, layer, servicesManager)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func measureDockerBlob(operation string, imagePath string, blob DockerBlob, benchmarkResults *[]BenchmarkResult, transfer func() (time.Duration, error)) error {
+	duration, err := transfer()
+	if err != nil {
+		return err
+	}
+	*benchmarkResults = append(*benchmarkResults, *NewKBBenchmarkResult(operation, imagePath+" "+blob.Digest, float64(blob.Size)/1024, duration))
+	return nil
+}
@@ -0,0 +1,70 @@
+package benchmarkUtils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDockerImage(t *testing.T) {
+	image, err := GenerateDockerImage("benchmark-image", "1", 2, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, image.Layers, 2)
+	assert.Equal(t, 1024, image.Layers[0].Size)
+	assert.NotEqual(t, image.Layers[0].Digest, image.Layers[1].Digest)
+
+	var manifest map[string]interface{}
+	assert.NoError(t, json.Unmarshal(image.Manifest, &manifest))
+	assert.Equal(t, float64(2), manifest["schemaVersion"])
+	assert.Equal(t, dockerManifestMediaType, manifest["mediaType"])
+	assert.Equal(t, image.Config.Digest, manifest["config"].(map[string]interface{})["digest"])
+	assert.Len(t, manifest["layers"], 2)
+}
+
+func TestDockerPushAndPull(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	image, err := GenerateDockerImage("benchmark/image", "1", 1, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = PushDockerBlob("benchmark-docker-tests", image.Name, image.Layers[0], servicesManager)
+	assert.NoError(t, err)
+	_, err = PushDockerBlob("benchmark-docker-tests", image.Name, image.Config, servicesManager)
+	assert.NoError(t, err)
+	_, err = PushDockerManifest("benchmark-docker-tests", image, servicesManager)
+	assert.NoError(t, err)
+	assert.Contains(t, fake.artifacts, "benchmark-docker-tests/benchmark/image/"+image.Layers[0].Digest)
+	assert.Contains(t, fake.artifacts, "benchmark-docker-tests/benchmark/image/1/manifest.json")
+
+	_, err = PullDockerManifest("benchmark-docker-tests", image, servicesManager)
+	assert.NoError(t, err)
+	_, err = PullDockerBlob("benchmark-docker-tests", image.Name, image.Layers[0], servicesManager)
+	assert.NoError(t, err)
+
+	fake.artifacts["benchmark-docker-tests/benchmark/image/"+image.Layers[0].Digest] = []byte("corrupted")
+	_, err = PullDockerBlob("benchmark-docker-tests", image.Name, image.Layers[0], servicesManager)
+	assert.Error(t, err, "Expected an error when the pulled blob doesn't match its digest")
+}
+
+func TestMeasureDockerOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-docker-tests", Iterations: 2, Layers: 2, FileSizeInKb: 1, ImageName: "benchmark-image"}
+	assert.NoError(t, MeasureDockerOperationTimes(config, servicesManager, &results))
+	var operations []string
+	for _, result := range results[:len(results)/2] {
+		operations = append(operations, result.Operation)
+	}
+	assert.Equal(t, []string{"push-layer", "push-layer", "push-config", "push-manifest", "pull-manifest", "pull-config", "pull-layer", "pull-layer"}, operations)
+	assert.Len(t, results, 16)
+	assert.Equal(t, "1", results[0].Size)
+	assert.Equal(t, "benchmark-docker-tests/benchmark-image:2", results[11].FileName)
+	assert.Len(t, fake.downloaded, 8)
+}
@@ -0,0 +1,170 @@
+package benchmarkUtils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The prefix of the environment variables of the flags, the size flag is set by JFROG_BENCHMARK_SIZE.
+const EnvVarPrefix = "JFROG_BENCHMARK_"
+
+const (
+	flagSource    = "flag"
+	envVarSource  = "env"
+	defaultSource = "default"
+)
+
+// Flags whose values aren't written to the log.
+var secretFlags = []string{"password", "influxdb_token"}
+
+func GetEnvVarName(flagName string) string {
+	return EnvVarPrefix + strings.ToUpper(flagName)
+}
+
+// Returns the flags without their default values and without making them mandatory, so the CLI passes only the values
+// given on the command line, and the defaults are applied by EnvConfigValues after the environment variables. The
+// defaults are still shown in the description of the flags.
+func WithoutFlagDefaults(flags []components.Flag) []components.Flag {
+	var result []components.Flag
+	for _, flag := range flags {
+		if stringFlag, ok := flag.(components.StringFlag); ok {
+			if stringFlag.DefaultValue != "" {
+				stringFlag.Description = "[Default: " + stringFlag.DefaultValue + "] " + stringFlag.Description
+			}
+			stringFlag.DefaultValue = ""
+			stringFlag.Mandatory = false
+			flag = stringFlag
+		}
+		result = append(result, flag)
+	}
+	return result
+}
+
+// Returns the environment variables of the flags, which are listed in the help of the command.
+func GetFlagEnvVars(flags []components.Flag) []components.EnvVar {
+	var envVars []components.EnvVar
+	for _, flag := range flags {
+		envVar := components.EnvVar{Name: GetEnvVarName(flag.GetName()), Description: "Sets the " + flag.GetName() + " flag."}
+		switch typedFlag := flag.(type) {
+		case components.StringFlag:
+			envVar.Default = typedFlag.DefaultValue
+		case components.BoolFlag:
+			envVar.Default = strconv.FormatBool(typedFlag.DefaultValue)
+		}
+		envVars = append(envVars, envVar)
+	}
+	return envVars
+}
+
+// The values of the flags of a command, where a flag that isn't given on the command line is taken from its
+// environment variable, and then from its default value.
+type EnvConfigValues struct {
+	flags       ConfigValues
+	definitions []components.Flag
+	// The command line arguments, the values of the flags don't tell whether a bool flag was set to false or not given.
+	args []string
+}
+
+// The flag definitions are the ones of the command, with their default values.
+func NewEnvConfigValues(flags ConfigValues, definitions []components.Flag) *EnvConfigValues {
+	return &EnvConfigValues{flags: flags, definitions: definitions, args: os.Args[1:]}
+}
+
+func (values *EnvConfigValues) GetStringFlagValue(name string) string {
+	value, _ := values.getStringFlagValue(name)
+	return value
+}
+
+// A bool flag set to false on the command line, such as --same_file=false, overrides its environment variable. An
+// environment variable that isn't a bool is ignored, ParseBoolFlagValue returns its error.
+func (values *EnvConfigValues) GetBoolFlagValue(name string) bool {
+	value, _, _ := values.getBoolFlagValue(name)
+	return value
+}
+
+// Returns the value of the bool flag the same way as GetBoolFlagValue, or an error naming the environment variable if
+// its value isn't a bool, such as yes.
+func (values *EnvConfigValues) ParseBoolFlagValue(name string) (bool, error) {
+	value, _, err := values.getBoolFlagValue(name)
+	return value, err
+}
+
+// Logs the value of every flag and whether it comes from the command line, the environment or the default value.
+func (values *EnvConfigValues) LogEffectiveConfig() {
+	log.Info("Running with the following configuration:")
+	for _, definition := range values.definitions {
+		name := definition.GetName()
+		var value, source string
+		if _, ok := definition.(components.BoolFlag); ok {
+			var boolValue bool
+			boolValue, source, _ = values.getBoolFlagValue(name)
+			value = strconv.FormatBool(boolValue)
+		} else {
+			value, source = values.getStringFlagValue(name)
+		}
+		if value != "" && contains(secretFlags, name) {
+			value = "***"
+		}
+		log.Info(fmt.Sprintf("  %s = %s (%s)", name, value, source))
+	}
+}
+
+func (values *EnvConfigValues) getStringFlagValue(name string) (string, string) {
+	if value := values.flags.GetStringFlagValue(name); value != "" {
+		return value, flagSource
+	}
+	if value, ok := os.LookupEnv(GetEnvVarName(name)); ok && value != "" {
+		return value, envVarSource
+	}
+	for _, definition := range values.definitions {
+		if stringFlag, ok := definition.(components.StringFlag); ok && stringFlag.Name == name {
+			return stringFlag.DefaultValue, defaultSource
+		}
+	}
+	return "", defaultSource
+}
+
+// An environment variable that isn't a bool is returned as an error along with the default value.
+func (values *EnvConfigValues) getBoolFlagValue(name string) (bool, string, error) {
+	if value := values.flags.GetBoolFlagValue(name); value || values.isFlagSet(name) {
+		return value, flagSource, nil
+	}
+	var envVarErr error
+	if envValue, ok := os.LookupEnv(GetEnvVarName(name)); ok && envValue != "" {
+		value, err := strconv.ParseBool(strings.TrimSpace(envValue))
+		if err == nil {
+			return value, envVarSource, nil
+		}
+		envVarErr = errors.New("The value [" + envValue + "] of " + GetEnvVarName(name) + " must be true or false")
+	}
+	for _, definition := range values.definitions {
+		if boolFlag, ok := definition.(components.BoolFlag); ok && boolFlag.Name == name {
+			return boolFlag.DefaultValue, defaultSource, envVarErr
+		}
+	}
+	return false, defaultSource, envVarErr
+}
+
+// Returns whether the flag is given on the command line, either as --name, --name=value or -name=value. The arguments
+// after a "--" argument aren't flags.
+func (values *EnvConfigValues) isFlagSet(name string) bool {
+	for _, arg := range values.args {
+		if arg == "--" {
+			return false
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		flag := strings.TrimLeft(arg, "-")
+		if flag == name || strings.HasPrefix(flag, name+"=") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package benchmarkUtils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/stretchr/testify/assert"
+)
+
+var testEnvFlags = []components.Flag{
+	components.StringFlag{Name: "size", Description: "Size of the files.", DefaultValue: "50", Mandatory: true},
+	components.StringFlag{Name: "iterations", DefaultValue: "30"},
+	components.StringFlag{Name: "repo_name", DefaultValue: "benchmark-up-tests"},
+	components.StringFlag{Name: "password"},
+	components.BoolFlag{Name: "same_file"},
+}
+
+func TestGetEnvVarName(t *testing.T) {
+	assert.Equal(t, "JFROG_BENCHMARK_SIZE", GetEnvVarName("size"))
+	assert.Equal(t, "JFROG_BENCHMARK_REPO_NAME", GetEnvVarName("repo_name"))
+}
+
+func TestWithoutFlagDefaults(t *testing.T) {
+	flags := WithoutFlagDefaults(testEnvFlags)
+	assert.Equal(t, components.StringFlag{Name: "size", Description: "[Default: 50] Size of the files."}, flags[0])
+	assert.Equal(t, components.StringFlag{Name: "password"}, flags[3])
+	assert.Equal(t, testEnvFlags[4], flags[4])
+	// The definitions keep their defaults.
+	assert.Equal(t, "50", testEnvFlags[0].(components.StringFlag).DefaultValue)
+}
+
+func TestGetFlagEnvVars(t *testing.T) {
+	envVars := GetFlagEnvVars(testEnvFlags)
+	if assert.Len(t, envVars, 5) {
+		assert.Equal(t, components.EnvVar{Name: "JFROG_BENCHMARK_SIZE", Default: "50", Description: "Sets the size flag."}, envVars[0])
+		assert.Equal(t, components.EnvVar{Name: "JFROG_BENCHMARK_SAME_FILE", Default: "false", Description: "Sets the same_file flag."}, envVars[4])
+	}
+}
+
+func TestEnvConfigValuesBoolFlagSetToFalse(t *testing.T) {
+	assert.NoError(t, os.Setenv("JFROG_BENCHMARK_SAME_FILE", "true"))
+	defer os.Unsetenv("JFROG_BENCHMARK_SAME_FILE")
+	values := NewEnvConfigValues(testConfigValues{"same_file": "false"}, testEnvFlags)
+
+	values.args = []string{"up", "--same_file=false"}
+	value, source, err := values.getBoolFlagValue("same_file")
+	assert.NoError(t, err)
+	assert.False(t, value)
+	assert.Equal(t, flagSource, source)
+
+	// A flag that isn't given on the command line is taken from the environment variable.
+	values.args = []string{"up", "--size=10", "--", "--same_file=false"}
+	value, source, err = values.getBoolFlagValue("same_file")
+	assert.NoError(t, err)
+	assert.True(t, value)
+	assert.Equal(t, envVarSource, source)
+}
+
+func TestEnvConfigValuesInvalidBool(t *testing.T) {
+	assert.NoError(t, os.Setenv("JFROG_BENCHMARK_SAME_FILE", "yes"))
+	defer os.Unsetenv("JFROG_BENCHMARK_SAME_FILE")
+	values := NewEnvConfigValues(testConfigValues{}, testEnvFlags)
+	values.args = []string{"up"}
+
+	config := NewBenchmarkConfig(UploadOperation)
+	reader := NewConfigReader(values)
+	reader.ReadBool("same_file", &config.SameFile)
+	assert.EqualError(t, reader.Err(), "The value [yes] of JFROG_BENCHMARK_SAME_FILE must be true or false")
+	assert.False(t, config.SameFile)
+
+	// The flag given on the command line is used without parsing the environment variable.
+	values.args = []string{"up", "--same_file"}
+	values.flags = testConfigValues{"same_file": "true"}
+	reader = NewConfigReader(values)
+	reader.ReadBool("same_file", &config.SameFile)
+	assert.NoError(t, reader.Err())
+	assert.True(t, config.SameFile)
+}
+
+func TestEnvConfigValues(t *testing.T) {
+	for name, value := range map[string]string{"JFROG_BENCHMARK_SIZE": "10", "JFROG_BENCHMARK_ITERATIONS": "5", "JFROG_BENCHMARK_SAME_FILE": "true"} {
+		assert.NoError(t, os.Setenv(name, value))
+		defer os.Unsetenv(name)
+	}
+	values := NewEnvConfigValues(testConfigValues{"size": "20", "password": "secret"}, testEnvFlags)
+
+	// The flag is used before the environment variable, which is used before the default value.
+	assert.Equal(t, "20", values.GetStringFlagValue("size"))
+	assert.Equal(t, "5", values.GetStringFlagValue("iterations"))
+	assert.Equal(t, "benchmark-up-tests", values.GetStringFlagValue("repo_name"))
+	assert.Equal(t, "secret", values.GetStringFlagValue("password"))
+	assert.True(t, values.GetBoolFlagValue("same_file"))
+	assert.Empty(t, values.GetStringFlagValue("url"))
+
+	config := NewBenchmarkConfig(UploadOperation)
+	reader := NewConfigReader(values)
+	reader.ReadInt("size", &config.FilesSizesInMb)
+	reader.ReadInt("iterations", &config.Iterations)
+	assert.NoError(t, reader.Err())
+	assert.Equal(t, 20, config.FilesSizesInMb)
+	assert.Equal(t, 5, config.Iterations)
+}
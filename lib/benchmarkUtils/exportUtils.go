@@ -0,0 +1,378 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The job the results are pushed as when no other job is configured.
+const DefaultPushJob = "jfrog_benchmark"
+
+const pushTimeout = 30 * time.Second
+
+// The tags are Prometheus labels, and job, operation and phase are set by the plugin.
+var (
+	pushTagNameRegexp  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	reservedPushTags   = []string{"job", "operation", "phase", "stat"}
+	influxEscapedChars = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// An operation of a run, the time is when it ended.
+type OperationSample struct {
+	Time      time.Time
+	Operation string
+	Phase     string
+	Bytes     int64
+	Duration  time.Duration
+	Failed    bool
+}
+
+// The summary of the measured operations of a type, the throughput is the average of the throughputs of the successful
+// operations, as in the stats printed by the commands.
+type OperationSummary struct {
+	Operation     string
+	Count         int
+	Errors        int
+	Bytes         int64
+	MinDuration   time.Duration
+	AvgDuration   time.Duration
+	P50Duration   time.Duration
+	P95Duration   time.Duration
+	P99Duration   time.Duration
+	MaxDuration   time.Duration
+	AvgThroughput float64
+}
+
+// Groups the samples of the measure phase by their operation and summarizes the successful operations of each group, the
+// failed ones are only counted as errors.
+func SummarizeOperations(samples []OperationSample) []OperationSummary {
+	var summaries []OperationSummary
+	var durations [][]time.Duration
+	var throughputs []float64
+	indexes := map[string]int{}
+	for _, sample := range samples {
+		if sample.Phase != MeasurePhase {
+			continue
+		}
+		i, ok := indexes[sample.Operation]
+		if !ok {
+			i = len(summaries)
+			indexes[sample.Operation] = i
+			summaries = append(summaries, OperationSummary{Operation: sample.Operation})
+			durations = append(durations, nil)
+			throughputs = append(throughputs, 0)
+		}
+		if sample.Failed {
+			summaries[i].Errors++
+			continue
+		}
+		summaries[i].Count++
+		summaries[i].Bytes += sample.Bytes
+		durations[i] = append(durations[i], sample.Duration)
+		if sample.Duration > 0 {
+			throughputs[i] += float64(sample.Bytes) / 1024 / 1024 / sample.Duration.Seconds()
+		}
+	}
+	for i := range summaries {
+		if summaries[i].Count == 0 {
+			continue
+		}
+		var totalDuration time.Duration
+		for _, duration := range durations[i] {
+			totalDuration += duration
+		}
+		summaries[i].MinDuration = GetPercentile(durations[i], 0)
+		summaries[i].AvgDuration = totalDuration / time.Duration(summaries[i].Count)
+		summaries[i].P50Duration = GetPercentile(durations[i], 50)
+		summaries[i].P95Duration = GetPercentile(durations[i], 95)
+		summaries[i].P99Duration = GetPercentile(durations[i], 99)
+		summaries[i].MaxDuration = GetPercentile(durations[i], 100)
+		summaries[i].AvgThroughput = throughputs[i] / float64(summaries[i].Count)
+	}
+	return summaries
+}
+
+// Records the operations of a run as they are measured, so they are pushed when the run ends.
+type operationRecorder struct {
+	mutex   sync.Mutex
+	samples []OperationSample
+}
+
+func (recorder *operationRecorder) ObserveOperation(operation string, phase string, bytes int64, duration time.Duration, err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.samples = append(recorder.samples, OperationSample{Time: time.Now(), Operation: operation, Phase: phase, Bytes: bytes,
+		Duration: duration, Failed: err != nil})
+}
+
+func (recorder *operationRecorder) stop() []OperationSample {
+	removeOperationObserver(recorder)
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.samples
+}
+
+func isPushEnabled(st *BenchmarkConfig) bool {
+	return st.PushGatewayUrl != "" || st.InfluxDbUrl != ""
+}
+
+// Pushes the summary of the operations of the run, and their samples if the config has push samples, to the
+// Pushgateway and the InfluxDB of the config. Nothing is pushed if the run doesn't record its operations, see
+// ResultsMetadata.StartMonitors.
+func PushResults(metadata *ResultsMetadata) error {
+	if metadata.operationRecorder == nil {
+		return nil
+	}
+	metadata.end()
+	st := metadata.Config
+	var firstError error
+	push := func(target string, method string, targetUrl string, contentType string, body []byte, headers map[string]string) {
+		err := sendPush(method, targetUrl, contentType, body, headers)
+		if err != nil {
+			log.Error("Failed to push the results to " + target + " - " + err.Error())
+			if firstError == nil {
+				firstError = err
+			}
+			return
+		}
+		log.Info("Pushed the results to " + target)
+	}
+	if st.PushGatewayUrl != "" {
+		var body bytes.Buffer
+		if err := WritePushGatewayMetrics(&body, metadata, metadata.operationSamples, st.PushSamples); err != nil {
+			return err
+		}
+		// The Pushgateway replaces the metrics of the group of the job and the tags on PUT.
+		push("the Pushgateway", http.MethodPut, GetPushGatewayUrl(st.PushGatewayUrl, st.PushJob, st.PushTags), "text/plain; version=0.0.4",
+			body.Bytes(), nil)
+	}
+	if st.InfluxDbUrl != "" {
+		var body bytes.Buffer
+		if err := WriteInfluxLines(&body, metadata, metadata.operationSamples, st.PushSamples); err != nil {
+			return err
+		}
+		headers := map[string]string{}
+		if st.InfluxDbToken != "" {
+			headers["Authorization"] = "Token " + st.InfluxDbToken
+		}
+		push("InfluxDB", http.MethodPost, st.InfluxDbUrl, "text/plain; charset=utf-8", body.Bytes(), headers)
+	}
+	return firstError
+}
+
+func sendPush(method string, targetUrl string, contentType string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequest(method, targetUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := (&http.Client{Timeout: pushTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return errors.New("Response: " + resp.Status + "\n" + string(respBody))
+	}
+	return nil
+}
+
+// Returns the URL of the group of the job and the tags on the Pushgateway, such as
+// http://localhost:9091/metrics/job/jfrog_benchmark/env/ci. Values that can't be part of a path are base64 encoded.
+func GetPushGatewayUrl(pushGatewayUrl string, job string, tags map[string]string) string {
+	pushUrl := strings.TrimSuffix(pushGatewayUrl, "/") + "/metrics/" + encodePushGroupingLabel("job", job)
+	for _, name := range sortedTagNames(tags) {
+		pushUrl += "/" + encodePushGroupingLabel(name, tags[name])
+	}
+	return pushUrl
+}
+
+func encodePushGroupingLabel(name string, value string) string {
+	if value == "" || strings.Contains(value, "/") {
+		encoded := base64.RawURLEncoding.EncodeToString([]byte(value))
+		if encoded == "" {
+			encoded = "="
+		}
+		return name + "@base64/" + encoded
+	}
+	return name + "/" + url.PathEscape(value)
+}
+
+// Writes the summary of the run as gauges in the Prometheus text format, and the histograms and counters of the live
+// metrics of the samples if withSamples is set, as a Pushgateway can't store separate samples of an operation.
+func WritePushGatewayMetrics(writer io.Writer, metadata *ResultsMetadata, samples []OperationSample, withSamples bool) error {
+	summaries := SummarizeOperations(samples)
+	bufferedWriter := bufio.NewWriter(writer)
+	writeGauge := func(name string, help string, getValues func(summary OperationSummary) map[string]float64) {
+		fmt.Fprintf(bufferedWriter, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, summary := range summaries {
+			values := getValues(summary)
+			var labels []string
+			for label := range values {
+				labels = append(labels, label)
+			}
+			sort.Strings(labels)
+			for _, label := range labels {
+				fmt.Fprintf(bufferedWriter, "%s{operation=%s%s} %s\n", name, strconv.Quote(summary.Operation), label,
+					formatMetricValue(values[label]))
+			}
+		}
+	}
+	single := func(value float64) map[string]float64 { return map[string]float64{"": value} }
+	writeGauge("jfrog_benchmark_summary_operations", "The number of successful measured operations.",
+		func(summary OperationSummary) map[string]float64 { return single(float64(summary.Count)) })
+	writeGauge("jfrog_benchmark_summary_errors", "The number of failed measured operations.",
+		func(summary OperationSummary) map[string]float64 { return single(float64(summary.Errors)) })
+	writeGauge("jfrog_benchmark_summary_bytes", "The bytes transferred by the successful measured operations.",
+		func(summary OperationSummary) map[string]float64 { return single(float64(summary.Bytes)) })
+	writeGauge("jfrog_benchmark_summary_duration_seconds", "The stats of the duration of the successful measured operations.",
+		func(summary OperationSummary) map[string]float64 {
+			return map[string]float64{
+				`,stat="min"`: summary.MinDuration.Seconds(),
+				`,stat="avg"`: summary.AvgDuration.Seconds(),
+				`,stat="p50"`: summary.P50Duration.Seconds(),
+				`,stat="p95"`: summary.P95Duration.Seconds(),
+				`,stat="p99"`: summary.P99Duration.Seconds(),
+				`,stat="max"`: summary.MaxDuration.Seconds(),
+			}
+		})
+	writeGauge("jfrog_benchmark_summary_throughput_megabytes_per_second", "The average throughput of the successful measured operations.",
+		func(summary OperationSummary) map[string]float64 { return single(summary.AvgThroughput) })
+	fmt.Fprintln(bufferedWriter, "# HELP jfrog_benchmark_summary_run_duration_seconds The duration of the run.")
+	fmt.Fprintln(bufferedWriter, "# TYPE jfrog_benchmark_summary_run_duration_seconds gauge")
+	fmt.Fprintf(bufferedWriter, "jfrog_benchmark_summary_run_duration_seconds %s\n", formatMetricValue(metadata.EndTime.Sub(metadata.StartTime).Seconds()))
+	if metadata.ClientHealth != nil {
+		fmt.Fprintln(bufferedWriter, "# HELP jfrog_benchmark_summary_client_saturated Whether the client was saturated during the run.")
+		fmt.Fprintln(bufferedWriter, "# TYPE jfrog_benchmark_summary_client_saturated gauge")
+		fmt.Fprintf(bufferedWriter, "jfrog_benchmark_summary_client_saturated %d\n", boolToInt(metadata.ClientHealth.Saturated()))
+	}
+	if err := bufferedWriter.Flush(); err != nil {
+		return err
+	}
+	if !withSamples {
+		return nil
+	}
+	metrics := NewLiveMetrics()
+	for _, sample := range samples {
+		var err error
+		if sample.Failed {
+			err = errors.New("failed")
+		}
+		metrics.ObserveOperation(sample.Operation, sample.Phase, sample.Bytes, sample.Duration, err)
+	}
+	return metrics.Write(writer)
+}
+
+// Writes the summary of every operation of the run as a point in the InfluxDB line protocol at the end time of the run,
+// and every sample as a point at its own time if withSamples is set.
+func WriteInfluxLines(writer io.Writer, metadata *ResultsMetadata, samples []OperationSample, withSamples bool) error {
+	bufferedWriter := bufio.NewWriter(writer)
+	st := metadata.Config
+	for _, summary := range SummarizeOperations(samples) {
+		tags := formatInfluxTags(st.PushJob, st.PushTags, map[string]string{"operation": summary.Operation})
+		fmt.Fprintf(bufferedWriter, "jfrog_benchmark_summary%s count=%di,errors=%di,bytes=%di,min_duration_seconds=%s,"+
+			"avg_duration_seconds=%s,p50_duration_seconds=%s,p95_duration_seconds=%s,p99_duration_seconds=%s,max_duration_seconds=%s,"+
+			"throughput_megabytes_per_second=%s,run_duration_seconds=%s", tags, summary.Count, summary.Errors, summary.Bytes,
+			formatMetricValue(summary.MinDuration.Seconds()), formatMetricValue(summary.AvgDuration.Seconds()),
+			formatMetricValue(summary.P50Duration.Seconds()), formatMetricValue(summary.P95Duration.Seconds()),
+			formatMetricValue(summary.P99Duration.Seconds()), formatMetricValue(summary.MaxDuration.Seconds()),
+			formatMetricValue(summary.AvgThroughput), formatMetricValue(metadata.EndTime.Sub(metadata.StartTime).Seconds()))
+		if metadata.ClientHealth != nil {
+			fmt.Fprintf(bufferedWriter, ",client_saturated=%t", metadata.ClientHealth.Saturated())
+		}
+		fmt.Fprintf(bufferedWriter, " %d\n", metadata.EndTime.UnixNano())
+	}
+	if withSamples {
+		for _, sample := range samples {
+			tags := formatInfluxTags(st.PushJob, st.PushTags, map[string]string{"operation": sample.Operation, "phase": sample.Phase})
+			fmt.Fprintf(bufferedWriter, "jfrog_benchmark_operation%s duration_seconds=%s,bytes=%di,failed=%t %d\n", tags,
+				formatMetricValue(sample.Duration.Seconds()), sample.Bytes, sample.Failed, sample.Time.UnixNano())
+		}
+	}
+	return bufferedWriter.Flush()
+}
+
+// Returns the tags of a point sorted by their names, as recommended by InfluxDB.
+func formatInfluxTags(job string, tags map[string]string, pointTags map[string]string) string {
+	allTags := map[string]string{"job": job}
+	for name, value := range tags {
+		allTags[name] = value
+	}
+	for name, value := range pointTags {
+		allTags[name] = value
+	}
+	var formatted string
+	for _, name := range sortedTagNames(allTags) {
+		// InfluxDB doesn't accept empty tag values.
+		if allTags[name] != "" {
+			formatted += "," + influxEscapedChars.Replace(name) + "=" + influxEscapedChars.Replace(allTags[name])
+		}
+	}
+	return formatted
+}
+
+func sortedTagNames(tags map[string]string) []string {
+	var names []string
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// Parses comma separated name=value tags, such as env=ci,branch=main.
+func ParsePushTags(tags string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for _, tag := range strings.Split(tags, ",") {
+		parts := strings.SplitN(strings.TrimSpace(tag), "=", 2)
+		if len(parts) != 2 || !pushTagNameRegexp.MatchString(parts[0]) {
+			return nil, errors.New("Push tags must be comma separated name=value pairs, such as env=ci,branch=main")
+		}
+		if contains(reservedPushTags, parts[0]) {
+			return nil, errors.New("The push tag [" + parts[0] + "] is set by the plugin")
+		}
+		parsed[parts[0]] = parts[1]
+	}
+	return parsed, nil
+}
+
+func ValidatePushInput(cliConfig *BenchmarkConfig) error {
+	for _, pushUrl := range []string{cliConfig.PushGatewayUrl, cliConfig.InfluxDbUrl} {
+		if pushUrl != "" && !UrlStartsWithHttpMethod(pushUrl) {
+			return errors.New("The push URL [" + pushUrl + "] must start with http:// or https://")
+		}
+	}
+	if cliConfig.PushSamples && !isPushEnabled(cliConfig) {
+		return errors.New("The push_samples option requires push_gateway_url or influxdb_url")
+	}
+	if isPushEnabled(cliConfig) && cliConfig.PushJob == "" {
+		return errors.New("Push job must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package benchmarkUtils
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeOperations(t *testing.T) {
+	samples := []OperationSample{
+		{Operation: "upload", Phase: WarmupPhase, Bytes: 1024 * 1024, Duration: time.Minute},
+		{Operation: "upload", Phase: MeasurePhase, Bytes: 1024 * 1024, Duration: time.Second},
+		{Operation: "upload", Phase: MeasurePhase, Bytes: 1024 * 1024, Duration: 4 * time.Second},
+		{Operation: "upload", Phase: MeasurePhase, Bytes: 1024 * 1024, Duration: 3 * time.Second, Failed: true},
+		{Operation: "download", Phase: MeasurePhase, Bytes: 2 * 1024 * 1024, Duration: 2 * time.Second},
+	}
+	assert.Equal(t, []OperationSummary{
+		{Operation: "upload", Count: 2, Errors: 1, Bytes: 2 * 1024 * 1024, MinDuration: time.Second, AvgDuration: 2500 * time.Millisecond,
+			P50Duration: time.Second, P95Duration: 4 * time.Second, P99Duration: 4 * time.Second, MaxDuration: 4 * time.Second,
+			AvgThroughput: 0.625},
+		{Operation: "download", Count: 1, Bytes: 2 * 1024 * 1024, MinDuration: 2 * time.Second, AvgDuration: 2 * time.Second,
+			P50Duration: 2 * time.Second, P95Duration: 2 * time.Second, P99Duration: 2 * time.Second, MaxDuration: 2 * time.Second,
+			AvgThroughput: 1},
+	}, SummarizeOperations(samples))
+	assert.Empty(t, SummarizeOperations(nil))
+}
+
+func TestGetPushGatewayUrl(t *testing.T) {
+	assert.Equal(t, "http://localhost:9091/metrics/job/jfrog_benchmark", GetPushGatewayUrl("http://localhost:9091/", "jfrog_benchmark", nil))
+	assert.Equal(t, "http://localhost:9091/metrics/job/ci/branch@base64/ZmVhdHVyZS94/empty@base64/=/env/ci%20runner",
+		GetPushGatewayUrl("http://localhost:9091", "ci", map[string]string{"env": "ci runner", "branch": "feature/x", "empty": ""}))
+}
+
+func TestParsePushTags(t *testing.T) {
+	tags, err := ParsePushTags("env=ci, branch=main,empty=")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "ci", "branch": "main", "empty": ""}, tags)
+	for _, invalid := range []string{"env", "env=ci,", "1env=ci", "env-name=ci"} {
+		_, err = ParsePushTags(invalid)
+		assert.EqualError(t, err, "Push tags must be comma separated name=value pairs, such as env=ci,branch=main", invalid)
+	}
+	_, err = ParsePushTags("phase=1")
+	assert.EqualError(t, err, "The push tag [phase] is set by the plugin")
+}
+
+func TestWriteInfluxLines(t *testing.T) {
+	start := time.Date(2023, 2, 21, 11, 30, 0, 0, time.UTC)
+	metadata := &ResultsMetadata{StartTime: start, EndTime: start.Add(10 * time.Second),
+		Config:       &BenchmarkConfig{PushJob: "ci", PushTags: map[string]string{"env": "ci runner", "branch": "a,b"}},
+		ClientHealth: &ClientHealth{}}
+	samples := []OperationSample{
+		{Time: start.Add(time.Second), Operation: "upload", Phase: WarmupPhase, Bytes: 1024 * 1024, Duration: time.Second},
+		{Time: start.Add(3 * time.Second), Operation: "upload", Phase: MeasurePhase, Bytes: 1024 * 1024, Duration: 2 * time.Second},
+	}
+	var buffer bytes.Buffer
+	assert.NoError(t, WriteInfluxLines(&buffer, metadata, samples, false))
+	summary := `jfrog_benchmark_summary,branch=a\,b,env=ci\ runner,job=ci,operation=upload count=1i,errors=0i,bytes=1048576i,` +
+		"min_duration_seconds=2,avg_duration_seconds=2,p50_duration_seconds=2,p95_duration_seconds=2,p99_duration_seconds=2," +
+		"max_duration_seconds=2,throughput_megabytes_per_second=0.5,run_duration_seconds=10,client_saturated=false 1676979010000000000\n"
+	assert.Equal(t, summary, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, WriteInfluxLines(&buffer, metadata, samples, true))
+	assert.Equal(t, summary+
+		`jfrog_benchmark_operation,branch=a\,b,env=ci\ runner,job=ci,operation=upload,phase=warmup duration_seconds=1,bytes=1048576i,failed=false 1676979001000000000`+"\n"+
+		`jfrog_benchmark_operation,branch=a\,b,env=ci\ runner,job=ci,operation=upload,phase=measure duration_seconds=2,bytes=1048576i,failed=false 1676979003000000000`+"\n",
+		buffer.String())
+}
+
+func TestWritePushGatewayMetrics(t *testing.T) {
+	start := time.Date(2023, 2, 21, 11, 30, 0, 0, time.UTC)
+	metadata := &ResultsMetadata{StartTime: start, EndTime: start.Add(10 * time.Second), Config: &BenchmarkConfig{}}
+	samples := []OperationSample{{Operation: "download", Phase: MeasurePhase, Bytes: 1024 * 1024, Duration: 2 * time.Second}}
+	var buffer bytes.Buffer
+	assert.NoError(t, WritePushGatewayMetrics(&buffer, metadata, samples, false))
+	assert.Equal(t, `# HELP jfrog_benchmark_summary_operations The number of successful measured operations.
+# TYPE jfrog_benchmark_summary_operations gauge
+jfrog_benchmark_summary_operations{operation="download"} 1
+# HELP jfrog_benchmark_summary_errors The number of failed measured operations.
+# TYPE jfrog_benchmark_summary_errors gauge
+jfrog_benchmark_summary_errors{operation="download"} 0
+# HELP jfrog_benchmark_summary_bytes The bytes transferred by the successful measured operations.
+# TYPE jfrog_benchmark_summary_bytes gauge
+jfrog_benchmark_summary_bytes{operation="download"} 1048576
+# HELP jfrog_benchmark_summary_duration_seconds The stats of the duration of the successful measured operations.
+# TYPE jfrog_benchmark_summary_duration_seconds gauge
+jfrog_benchmark_summary_duration_seconds{operation="download",stat="avg"} 2
+jfrog_benchmark_summary_duration_seconds{operation="download",stat="max"} 2
+jfrog_benchmark_summary_duration_seconds{operation="download",stat="min"} 2
+jfrog_benchmark_summary_duration_seconds{operation="download",stat="p50"} 2
+jfrog_benchmark_summary_duration_seconds{operation="download",stat="p95"} 2
+jfrog_benchmark_summary_duration_seconds{operation="download",stat="p99"} 2
+# HELP jfrog_benchmark_summary_throughput_megabytes_per_second The average throughput of the successful measured operations.
+# TYPE jfrog_benchmark_summary_throughput_megabytes_per_second gauge
+jfrog_benchmark_summary_throughput_megabytes_per_second{operation="download"} 0.5
+# HELP jfrog_benchmark_summary_run_duration_seconds The duration of the run.
+# TYPE jfrog_benchmark_summary_run_duration_seconds gauge
+jfrog_benchmark_summary_run_duration_seconds 10
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, WritePushGatewayMetrics(&buffer, metadata, samples, true))
+	assert.Contains(t, buffer.String(), `jfrog_benchmark_operation_duration_seconds_count{operation="download",phase="measure"} 1`)
+}
+
+type pushRequest struct {
+	method        string
+	path          string
+	query         string
+	authorization string
+	body          string
+}
+
+func TestPushResults(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []pushRequest
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mutex.Lock()
+		requests = append(requests, pushRequest{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization"), string(body)})
+		mutex.Unlock()
+		if r.URL.Path == "/failing/write" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+
+	config := &BenchmarkConfig{Operation: UploadOperation, RepositoryName: "benchmark-up-tests", FilesSizesInMb: 1,
+		PushGatewayUrl: target.URL, InfluxDbUrl: target.URL + "/write?db=benchmark", InfluxDbToken: "secret", PushJob: "ci",
+		PushTags: map[string]string{"env": "ci"}}
+	metadata := NewResultsMetadata(config, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	var results []BenchmarkResult
+	assert.NoError(t, MeasureOperationTimes(config, fileNames, servicesManager, &results))
+	assert.NoError(t, PushResults(metadata))
+
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, http.MethodPut, requests[0].method)
+		assert.Equal(t, "/metrics/job/ci/env/ci", requests[0].path)
+		assert.Contains(t, requests[0].body, `jfrog_benchmark_summary_operations{operation="upload"} 2`)
+		assert.Contains(t, requests[0].body, "jfrog_benchmark_summary_client_saturated ")
+		assert.NotContains(t, requests[0].body, "jfrog_benchmark_operation_duration_seconds")
+
+		assert.Equal(t, http.MethodPost, requests[1].method)
+		assert.Equal(t, "/write", requests[1].path)
+		assert.Equal(t, "db=benchmark", requests[1].query)
+		assert.Equal(t, "Token secret", requests[1].authorization)
+		assert.Contains(t, requests[1].body, "jfrog_benchmark_summary,env=ci,job=ci,operation=upload count=2i,errors=0i,bytes=2097152i,")
+	}
+
+	// The operations are no longer recorded once the run ends, and a failed push is returned.
+	assert.NoError(t, MeasureOperationTimes(config, fileNames[:1], servicesManager, &results))
+	requests = nil
+	config.PushGatewayUrl = ""
+	config.InfluxDbUrl = target.URL + "/failing/write"
+	assert.Error(t, PushResults(metadata))
+	if assert.Len(t, requests, 1) {
+		assert.Contains(t, requests[0].body, "count=2i")
+	}
+
+	// Nothing is pushed by runs without push targets.
+	requests = nil
+	metadata = NewResultsMetadata(&BenchmarkConfig{Operation: UploadOperation}, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	assert.NoError(t, PushResults(metadata))
+	assert.Empty(t, requests)
+}
+
+func TestPushRampResults(t *testing.T) {
+	var requests []pushRequest
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, pushRequest{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization"), string(body)})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-dl-tests/File1.txt": []byte("first artifact"),
+	})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+	filePath := "ramp-push-results.csv"
+	defer os.Remove(filePath)
+
+	config := &BenchmarkConfig{Operation: DownloadOperation, RepositoryName: "benchmark-dl-tests", FilesSizesInMb: 1,
+		Ramp: []int{2}, StepDuration: time.Second, PushGatewayUrl: target.URL, PushJob: "ci"}
+	metadata := NewResultsMetadata(config, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	var results []RampStepResult
+	assert.NoError(t, MeasureRampOperationTimes(config, []string{"/tmp/testfiles/File1.txt"}, servicesManager, &results))
+	assert.NoError(t, WriteRampResults(filePath, metadata, results))
+	assert.NoError(t, PushResults(metadata))
+
+	// The summary holds the operations of the ramp.
+	if assert.Len(t, requests, 1) && assert.Len(t, results, 1) {
+		assert.True(t, results[0].Operations > 0)
+		assert.Contains(t, requests[0].body, fmt.Sprintf(`jfrog_benchmark_summary_operations{operation="download"} %d`, results[0].Operations))
+	}
+}
+
+func TestValidatePushInput(t *testing.T) {
+	assert.NoError(t, ValidatePushInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidatePushInput(&BenchmarkConfig{PushGatewayUrl: "http://localhost:9091", PushJob: DefaultPushJob, PushSamples: true}))
+	assert.EqualError(t, ValidatePushInput(&BenchmarkConfig{InfluxDbUrl: "localhost:8086/write", PushJob: DefaultPushJob}),
+		"The push URL [localhost:8086/write] must start with http:// or https://")
+	assert.EqualError(t, ValidatePushInput(&BenchmarkConfig{PushSamples: true}),
+		"The push_samples option requires push_gateway_url or influxdb_url")
+	assert.EqualError(t, ValidatePushInput(&BenchmarkConfig{PushGatewayUrl: "http://localhost:9091"}), "Push job must not be empty")
+}
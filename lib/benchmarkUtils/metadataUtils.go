@@ -0,0 +1,173 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The version of the plugin, which is recorded in the metadata of the results.
+const PluginVersion = "v0.1.5"
+
+// The details of a run that aren't part of its results. They are written as a comment block before the results of the
+// run in the results file, and as a JSON object to a metadata file next to the results file, so results of different
+// server versions, clients and configs can be told apart.
+type ResultsMetadata struct {
+	PluginVersion string           `json:"pluginVersion"`
+	StartTime     time.Time        `json:"startTime"`
+	EndTime       time.Time        `json:"endTime"`
+	Server        ServerMetadata   `json:"server"`
+	Client        ClientMetadata   `json:"client"`
+	Config        *BenchmarkConfig `json:"config"`
+	ServerMetrics *ServerMetrics   `json:"serverMetrics,omitempty"`
+	ClientHealth  *ClientHealth    `json:"clientHealth,omitempty"`
+
+	serverMetricsPoller *serverMetricsPoller
+	clientMonitor       *clientMonitor
+	operationRecorder   *operationRecorder
+	operationSamples    []OperationSample
+}
+
+type ServerMetadata struct {
+	Url     string `json:"url"`
+	Version string `json:"version"`
+}
+
+type ClientMetadata struct {
+	Hostname  string `json:"hostname"`
+	Os        string `json:"os"`
+	Arch      string `json:"arch"`
+	Cpus      int    `json:"cpus"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Returns the metadata of a run of the config on the server of the services manager, the run starts now.
+func NewResultsMetadata(st *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager) *ResultsMetadata {
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Warn("Failed to get the hostname of the client - " + err.Error())
+	}
+	serverVersion, err := servicesManager.GetVersion()
+	if err != nil {
+		log.Warn("Failed to get the version of the server - " + err.Error())
+	}
+	return &ResultsMetadata{
+		PluginVersion: PluginVersion,
+		StartTime:     time.Now(),
+		Server:        ServerMetadata{Url: servicesManager.GetConfig().GetServiceDetails().GetUrl(), Version: serverVersion},
+		Client: ClientMetadata{Hostname: hostname, Os: runtime.GOOS, Arch: runtime.GOARCH, Cpus: runtime.NumCPU(),
+			GoVersion: runtime.Version()},
+		Config: st,
+	}
+}
+
+// Starts monitoring the resources of the client, collecting the server metrics if the config has a server metrics
+// interval, and recording the operations if the config pushes the results, until the run ends. It should be called right
+// before the measured operations, so the setup of the run isn't part of the monitoring.
+func (metadata *ResultsMetadata) StartMonitors(servicesManager artifactory.ArtifactoryServicesManager) {
+	metadata.clientMonitor = startClientMonitor(clientMonitorInterval)
+	if isPushEnabled(metadata.Config) {
+		metadata.operationRecorder = &operationRecorder{}
+		addOperationObserver(metadata.operationRecorder)
+	}
+	if metadata.Config.ServerMetricsInterval > 0 {
+		metadata.serverMetricsPoller = startServerMetricsPoller(metadata.Config, servicesManager)
+	}
+}
+
+// The run ends when its results are written, the first time they are.
+func (metadata *ResultsMetadata) end() {
+	if !metadata.EndTime.IsZero() {
+		return
+	}
+	metadata.EndTime = time.Now()
+	if metadata.serverMetricsPoller != nil {
+		metadata.ServerMetrics = NewServerMetrics(metadata.serverMetricsPoller.stop())
+	}
+	if metadata.operationRecorder != nil {
+		metadata.operationSamples = metadata.operationRecorder.stop()
+	}
+	if metadata.clientMonitor != nil {
+		metadata.ClientHealth = metadata.clientMonitor.stop()
+		for _, warning := range metadata.ClientHealth.Warnings {
+			log.Warn(warning + ", the results may be limited by the client rather than by Artifactory")
+		}
+	}
+}
+
+// Writes the metadata as comment lines, which precede the results of the run in the results file. Nothing is written for
+// results without metadata.
+func writeMetadataHeader(writer *bufio.Writer, metadata *ResultsMetadata) error {
+	if metadata == nil {
+		return nil
+	}
+	metadata.end()
+	config, err := json.Marshal(metadata.Config)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(writer, "# plugin version: "+metadata.PluginVersion)
+	fmt.Fprintln(writer, "# start time: "+metadata.StartTime.Format(time.RFC3339))
+	fmt.Fprintln(writer, "# end time: "+metadata.EndTime.Format(time.RFC3339))
+	fmt.Fprintln(writer, "# server url: "+metadata.Server.Url)
+	fmt.Fprintln(writer, "# server version: "+metadata.Server.Version)
+	fmt.Fprintf(writer, "# client: %s (%s/%s, %d CPUs, %s)\n", metadata.Client.Hostname, metadata.Client.Os, metadata.Client.Arch,
+		metadata.Client.Cpus, metadata.Client.GoVersion)
+	fmt.Fprintln(writer, "# config: "+string(config))
+	if health := metadata.ClientHealth; health != nil {
+		fmt.Fprintf(writer, "# client health: avg cpu %.1f%%, peak cpu %.1f%%, peak memory %.1f MB, peak goroutines %d, peak network %.2f MB/sec received %.2f MB/sec sent\n",
+			health.AvgCpuPercent, health.PeakCpuPercent, float64(health.PeakMemoryBytes)/1024/1024, health.PeakGoroutines,
+			health.PeakNetworkReceiveBytesPerSec/1024/1024, health.PeakNetworkTransmitBytesPerSec/1024/1024)
+		for _, warning := range health.Warnings {
+			fmt.Fprintln(writer, "# client warning: "+warning)
+		}
+	}
+	return nil
+}
+
+// Returns the path of the metadata file of the results file, results.csv has the results-metadata.json metadata file.
+func GetMetadataFilePath(resultsFilePath string) string {
+	return strings.TrimSuffix(resultsFilePath, filepath.Ext(resultsFilePath)) + "-metadata.json"
+}
+
+// Writes the metadata of the run to the metadata file next to its results file, and the samples of the server metrics
+// and of the client resources of the run to their files. The metadata of a previous run is replaced when the results are appended to its
+// file, its metadata is still in the comment block of the results file.
+func WriteResultsMetadata(resultsFilePath string, metadata *ResultsMetadata) error {
+	metadata.end()
+	if metadata.ServerMetrics != nil {
+		metadata.ServerMetrics.SamplesFile = GetServerMetricsFilePath(resultsFilePath)
+		err := WriteServerMetricsSamples(metadata.ServerMetrics.SamplesFile, metadata.ServerMetrics.Samples)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Collected %d samples of the server metrics, written to [%s]", len(metadata.ServerMetrics.Samples),
+			metadata.ServerMetrics.SamplesFile))
+	}
+	if metadata.ClientHealth != nil {
+		metadata.ClientHealth.SamplesFile = GetClientResourcesFilePath(resultsFilePath)
+		err := WriteClientResourcesSamples(metadata.ClientHealth.SamplesFile, metadata.ClientHealth.Samples)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	metadataFilePath := GetMetadataFilePath(resultsFilePath)
+	if err = ioutil.WriteFile(metadataFilePath, data, 0644); err != nil {
+		return errors.New("Failed to write the metadata file [" + metadataFilePath + "] - " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package benchmarkUtils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResultsMetadata(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	config := &BenchmarkConfig{Operation: UploadOperation, Iterations: 2}
+
+	metadata := NewResultsMetadata(config, servicesManager)
+	assert.Equal(t, PluginVersion, metadata.PluginVersion)
+	assert.Equal(t, "7.55.0", metadata.Server.Version)
+	assert.Equal(t, fake.URL+"/artifactory/", metadata.Server.Url)
+	assert.Equal(t, runtime.GOOS, metadata.Client.Os)
+	assert.Equal(t, runtime.NumCPU(), metadata.Client.Cpus)
+	assert.Equal(t, runtime.Version(), metadata.Client.GoVersion)
+	assert.Same(t, config, metadata.Config)
+	assert.False(t, metadata.StartTime.IsZero())
+	assert.True(t, metadata.EndTime.IsZero())
+}
+
+func TestWriteResultsWithMetadata(t *testing.T) {
+	filePath := "metadata-results.csv"
+	defer os.Remove(filePath)
+	start := time.Date(2023, 2, 21, 11, 30, 0, 0, time.UTC)
+	metadata := &ResultsMetadata{PluginVersion: "v1.0.0", StartTime: start, EndTime: start.Add(time.Minute),
+		Server: ServerMetadata{Url: "https://acme.jfrog.io/artifactory/", Version: "7.55.0"},
+		Client: ClientMetadata{Hostname: "ci-1", Os: "linux", Arch: "amd64", Cpus: 8, GoVersion: "go1.20"},
+		Config: &BenchmarkConfig{Operation: DownloadOperation, Iterations: 2, Password: "secret"}}
+	results := []BenchmarkResult{{"file1.dat", "1", "1s", "1.00", "download"}}
+	assert.NoError(t, WriteOperationResults(filePath, metadata, results))
+	// The results of every run are preceded by its metadata, the column names are written once.
+	assert.NoError(t, WriteOperationResults(filePath, metadata, results))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	header := "# plugin version: v1.0.0\n" +
+		"# start time: 2023-02-21T11:30:00Z\n" +
+		"# end time: 2023-02-21T11:31:00Z\n" +
+		"# server url: https://acme.jfrog.io/artifactory/\n" +
+		"# server version: 7.55.0\n" +
+		"# client: ci-1 (linux/amd64, 8 CPUs, go1.20)\n" +
+		`# config: {"operation":"download","iterations":2}` + "\n"
+	assert.Equal(t, header+"operation,file,size (MB),time taken (sec),speed (MB/sec)\n"+
+		"download,file1.dat,1,1s,1.00\n"+header+"download,file1.dat,1,1s,1.00\n", string(content))
+	assert.False(t, strings.Contains(string(content), "secret"))
+}
+
+func TestWriteResultsMetadata(t *testing.T) {
+	resultsFilePath := "warmup-results.csv"
+	metadataFilePath := GetMetadataFilePath(resultsFilePath)
+	assert.Equal(t, "warmup-results-metadata.json", metadataFilePath)
+	defer os.Remove(metadataFilePath)
+
+	metadata := &ResultsMetadata{PluginVersion: "v1.0.0", StartTime: time.Now(), Server: ServerMetadata{Version: "7.55.0"},
+		Config: &BenchmarkConfig{Operation: UploadOperation, Warmup: 5, Password: "secret"}}
+	assert.NoError(t, WriteResultsMetadata(resultsFilePath, metadata))
+	// The run ends when its metadata is written.
+	assert.False(t, metadata.EndTime.IsZero())
+
+	data, err := ioutil.ReadFile(metadataFilePath)
+	assert.NoError(t, err)
+	var written map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, "v1.0.0", written["pluginVersion"])
+	assert.Equal(t, map[string]interface{}{"url": "", "version": "7.55.0"}, written["server"])
+	assert.Equal(t, map[string]interface{}{"operation": "upload", "warmup": float64(5)}, written["config"])
+	assert.Contains(t, written, "startTime")
+	assert.Contains(t, written, "endTime")
+}
@@ -0,0 +1,266 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The phases of a run the live metrics are labeled with, the warm-up operations aren't part of the results.
+const (
+	WarmupPhase  = "warmup"
+	MeasurePhase = "measure"
+)
+
+// The upper bounds of the buckets of the histograms, in seconds and in MB per second.
+var (
+	durationBuckets   = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+	throughputBuckets = []float64{0.1, 0.5, 1, 5, 10, 25, 50, 100, 250, 500, 1000}
+)
+
+type metricsSeriesLabels struct {
+	operation string
+	phase     string
+}
+
+type histogram struct {
+	// The number of observations in every bucket, not including the observations of the previous buckets.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(buckets []float64, value float64) {
+	index := sort.SearchFloat64s(buckets, value)
+	if index < len(buckets) {
+		h.counts[index]++
+	}
+	h.sum += value
+	h.count++
+}
+
+type metricsSeries struct {
+	duration   histogram
+	throughput histogram
+	bytes      float64
+	errors     float64
+}
+
+// The metrics of the operations of a run as they are measured, labeled by the operation and the phase of the run. They
+// are exposed in the Prometheus text format, so long runs can be watched while they are running.
+type LiveMetrics struct {
+	mutex  sync.Mutex
+	series map[metricsSeriesLabels]*metricsSeries
+}
+
+func NewLiveMetrics() *LiveMetrics {
+	return &LiveMetrics{series: map[metricsSeriesLabels]*metricsSeries{}}
+}
+
+func (metrics *LiveMetrics) getSeries(operation string, phase string) *metricsSeries {
+	labels := metricsSeriesLabels{operation: operation, phase: phase}
+	series, ok := metrics.series[labels]
+	if !ok {
+		series = &metricsSeries{duration: histogram{counts: make([]uint64, len(durationBuckets))},
+			throughput: histogram{counts: make([]uint64, len(throughputBuckets))}}
+		metrics.series[labels] = series
+	}
+	return series
+}
+
+// Records an operation that transferred the given number of bytes, a failed operation is only counted as an error.
+func (metrics *LiveMetrics) ObserveOperation(operation string, phase string, bytes int64, duration time.Duration, err error) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	series := metrics.getSeries(operation, phase)
+	if err != nil {
+		series.errors++
+		return
+	}
+	series.duration.observe(durationBuckets, duration.Seconds())
+	if duration > 0 {
+		series.throughput.observe(throughputBuckets, float64(bytes)/1024/1024/duration.Seconds())
+	}
+	series.bytes += float64(bytes)
+}
+
+// Writes the metrics in the Prometheus text exposition format, the series are sorted by their labels.
+func (metrics *LiveMetrics) Write(writer io.Writer) error {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	var labels []metricsSeriesLabels
+	for seriesLabels := range metrics.series {
+		labels = append(labels, seriesLabels)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].operation != labels[j].operation {
+			return labels[i].operation < labels[j].operation
+		}
+		return labels[i].phase < labels[j].phase
+	})
+
+	bufferedWriter := bufio.NewWriter(writer)
+	writeHistogram := func(name string, help string, buckets []float64, getHistogram func(*metricsSeries) *histogram) {
+		fmt.Fprintf(bufferedWriter, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+		for _, seriesLabels := range labels {
+			h := getHistogram(metrics.series[seriesLabels])
+			var cumulativeCount uint64
+			for i, bucket := range buckets {
+				cumulativeCount += h.counts[i]
+				fmt.Fprintf(bufferedWriter, "%s_bucket%s %d\n", name, formatMetricLabels(seriesLabels, formatMetricValue(bucket)), cumulativeCount)
+			}
+			fmt.Fprintf(bufferedWriter, "%s_bucket%s %d\n", name, formatMetricLabels(seriesLabels, "+Inf"), h.count)
+			fmt.Fprintf(bufferedWriter, "%s_sum%s %s\n", name, formatMetricLabels(seriesLabels, ""), formatMetricValue(h.sum))
+			fmt.Fprintf(bufferedWriter, "%s_count%s %d\n", name, formatMetricLabels(seriesLabels, ""), h.count)
+		}
+	}
+	writeCounter := func(name string, help string, getValue func(*metricsSeries) float64) {
+		fmt.Fprintf(bufferedWriter, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		for _, seriesLabels := range labels {
+			fmt.Fprintf(bufferedWriter, "%s%s %s\n", name, formatMetricLabels(seriesLabels, ""), formatMetricValue(getValue(metrics.series[seriesLabels])))
+		}
+	}
+	writeHistogram("jfrog_benchmark_operation_duration_seconds", "The duration of the successful operations.", durationBuckets,
+		func(series *metricsSeries) *histogram { return &series.duration })
+	writeHistogram("jfrog_benchmark_operation_throughput_megabytes_per_second", "The throughput of the successful operations.",
+		throughputBuckets, func(series *metricsSeries) *histogram { return &series.throughput })
+	writeCounter("jfrog_benchmark_transferred_bytes_total", "The bytes transferred by the successful operations.",
+		func(series *metricsSeries) float64 { return series.bytes })
+	writeCounter("jfrog_benchmark_operation_errors_total", "The number of failed operations.",
+		func(series *metricsSeries) float64 { return series.errors })
+	return bufferedWriter.Flush()
+}
+
+func formatMetricLabels(labels metricsSeriesLabels, le string) string {
+	formatted := fmt.Sprintf("{operation=%s,phase=%s", strconv.Quote(labels.operation), strconv.Quote(labels.phase))
+	if le != "" {
+		formatted += ",le=" + strconv.Quote(le)
+	}
+	return formatted + "}"
+}
+
+func formatMetricValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Receives the operations of a run as they are measured.
+type operationObserver interface {
+	ObserveOperation(operation string, phase string, bytes int64, duration time.Duration, err error)
+}
+
+// The observers the operations are recorded to, such as the live metrics while the metrics server is running.
+var (
+	operationObserversMutex sync.Mutex
+	operationObservers      []operationObserver
+)
+
+func addOperationObserver(observer operationObserver) {
+	operationObserversMutex.Lock()
+	defer operationObserversMutex.Unlock()
+	operationObservers = append(operationObservers, observer)
+}
+
+func removeOperationObserver(observer operationObserver) {
+	operationObserversMutex.Lock()
+	defer operationObserversMutex.Unlock()
+	for i := range operationObservers {
+		if operationObservers[i] == observer {
+			operationObservers = append(operationObservers[:i:i], operationObservers[i+1:]...)
+			return
+		}
+	}
+}
+
+func observeOperation(operation string, phase string, bytes int64, duration time.Duration, err error) {
+	operationObserversMutex.Lock()
+	observers := operationObservers
+	operationObserversMutex.Unlock()
+	for _, observer := range observers {
+		observer.ObserveOperation(operation, phase, bytes, duration, err)
+	}
+}
+
+// Records an operation of the config on a single file.
+func observeFileOperation(st *BenchmarkConfig, phase string, duration time.Duration, err error) {
+	observeOperation(string(st.Operation), phase, int64(st.FilesSizesInMb)*1024*1024, duration, err)
+}
+
+// Serves the live metrics of the operations of the run on the /metrics path, until it is closed.
+type MetricsServer struct {
+	listener net.Listener
+	server   *http.Server
+	metrics  *LiveMetrics
+}
+
+func StartMetricsServer(listenAddress string) (*MetricsServer, error) {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, errors.New("Failed to listen on [" + listenAddress + "] for the metrics - " + err.Error())
+	}
+	metrics := NewLiveMetrics()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := metrics.Write(w); err != nil {
+			log.Debug("Failed to write the metrics - " + err.Error())
+		}
+	})
+	metricsServer := &MetricsServer{listener: listener, server: &http.Server{Handler: mux}, metrics: metrics}
+	go func() {
+		if err := metricsServer.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Warn("The metrics server stopped - " + err.Error())
+		}
+	}()
+	addOperationObserver(metrics)
+	log.Info("Serving the metrics of the run on http://" + listener.Addr().String() + "/metrics")
+	return metricsServer, nil
+}
+
+// Returns the address the server listens on, which has the actual port when the listen address has port 0.
+func (metricsServer *MetricsServer) Addr() string {
+	return metricsServer.listener.Addr().String()
+}
+
+func (metricsServer *MetricsServer) Close() error {
+	removeOperationObserver(metricsServer.metrics)
+	return metricsServer.server.Close()
+}
+
+// Starts the metrics server if the config has a metrics listen address, the returned function closes it.
+func StartMetricsServerIfNeeded(cliConfig *BenchmarkConfig) (func(), error) {
+	if cliConfig.MetricsListen == "" {
+		return func() {}, nil
+	}
+	metricsServer, err := StartMetricsServer(cliConfig.MetricsListen)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := metricsServer.Close(); err != nil {
+			log.Debug("Failed to close the metrics server - " + err.Error())
+		}
+	}, nil
+}
+
+func ValidateMetricsListenInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.MetricsListen == "" {
+		return nil
+	}
+	if _, port, err := net.SplitHostPort(cliConfig.MetricsListen); err != nil || port == "" {
+		return errors.New("Metrics listen address must be a host and a port, such as :9100 or 127.0.0.1:9100")
+	}
+	return nil
+}
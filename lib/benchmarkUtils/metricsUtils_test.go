@@ -0,0 +1,107 @@
+package benchmarkUtils
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteLiveMetrics(t *testing.T) {
+	durationBuckets, throughputBuckets = []float64{0.5, 1}, []float64{1, 10}
+	defer func() {
+		durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+		throughputBuckets = []float64{0.1, 0.5, 1, 5, 10, 25, 50, 100, 250, 500, 1000}
+	}()
+	metrics := NewLiveMetrics()
+	metrics.ObserveOperation("upload", MeasurePhase, 2*1024*1024, 500*time.Millisecond, nil)
+	metrics.ObserveOperation("upload", MeasurePhase, 2*1024*1024, 4*time.Second, nil)
+	metrics.ObserveOperation("upload", MeasurePhase, 2*1024*1024, time.Second, errors.New("failed"))
+	metrics.ObserveOperation("upload", WarmupPhase, 1024*1024, 100*time.Millisecond, nil)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, metrics.Write(&buffer))
+	assert.Equal(t, `# HELP jfrog_benchmark_operation_duration_seconds The duration of the successful operations.
+# TYPE jfrog_benchmark_operation_duration_seconds histogram
+jfrog_benchmark_operation_duration_seconds_bucket{operation="upload",phase="measure",le="0.5"} 1
+jfrog_benchmark_operation_duration_seconds_bucket{operation="upload",phase="measure",le="1"} 1
+jfrog_benchmark_operation_duration_seconds_bucket{operation="upload",phase="measure",le="+Inf"} 2
+jfrog_benchmark_operation_duration_seconds_sum{operation="upload",phase="measure"} 4.5
+jfrog_benchmark_operation_duration_seconds_count{operation="upload",phase="measure"} 2
+jfrog_benchmark_operation_duration_seconds_bucket{operation="upload",phase="warmup",le="0.5"} 1
+jfrog_benchmark_operation_duration_seconds_bucket{operation="upload",phase="warmup",le="1"} 1
+jfrog_benchmark_operation_duration_seconds_bucket{operation="upload",phase="warmup",le="+Inf"} 1
+jfrog_benchmark_operation_duration_seconds_sum{operation="upload",phase="warmup"} 0.1
+jfrog_benchmark_operation_duration_seconds_count{operation="upload",phase="warmup"} 1
+# HELP jfrog_benchmark_operation_throughput_megabytes_per_second The throughput of the successful operations.
+# TYPE jfrog_benchmark_operation_throughput_megabytes_per_second histogram
+jfrog_benchmark_operation_throughput_megabytes_per_second_bucket{operation="upload",phase="measure",le="1"} 1
+jfrog_benchmark_operation_throughput_megabytes_per_second_bucket{operation="upload",phase="measure",le="10"} 2
+jfrog_benchmark_operation_throughput_megabytes_per_second_bucket{operation="upload",phase="measure",le="+Inf"} 2
+jfrog_benchmark_operation_throughput_megabytes_per_second_sum{operation="upload",phase="measure"} 4.5
+jfrog_benchmark_operation_throughput_megabytes_per_second_count{operation="upload",phase="measure"} 2
+jfrog_benchmark_operation_throughput_megabytes_per_second_bucket{operation="upload",phase="warmup",le="1"} 0
+jfrog_benchmark_operation_throughput_megabytes_per_second_bucket{operation="upload",phase="warmup",le="10"} 1
+jfrog_benchmark_operation_throughput_megabytes_per_second_bucket{operation="upload",phase="warmup",le="+Inf"} 1
+jfrog_benchmark_operation_throughput_megabytes_per_second_sum{operation="upload",phase="warmup"} 10
+jfrog_benchmark_operation_throughput_megabytes_per_second_count{operation="upload",phase="warmup"} 1
+# HELP jfrog_benchmark_transferred_bytes_total The bytes transferred by the successful operations.
+# TYPE jfrog_benchmark_transferred_bytes_total counter
+jfrog_benchmark_transferred_bytes_total{operation="upload",phase="measure"} 4194304
+jfrog_benchmark_transferred_bytes_total{operation="upload",phase="warmup"} 1048576
+# HELP jfrog_benchmark_operation_errors_total The number of failed operations.
+# TYPE jfrog_benchmark_operation_errors_total counter
+jfrog_benchmark_operation_errors_total{operation="upload",phase="measure"} 1
+jfrog_benchmark_operation_errors_total{operation="upload",phase="warmup"} 0
+`, buffer.String())
+}
+
+func TestServeLiveMetrics(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+	config := &BenchmarkConfig{Operation: UploadOperation, RepositoryName: "benchmark-up-tests", FilesSizesInMb: 1, Warmup: 1}
+
+	metricsServer, err := StartMetricsServer("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, RunWarmupOperations(config, fileNames, servicesManager))
+	var results []BenchmarkResult
+	assert.NoError(t, MeasureOperationTimes(config, fileNames, servicesManager, &results))
+	assert.Len(t, results, 2)
+
+	resp, err := http.Get("http://" + metricsServer.Addr() + "/metrics")
+	if assert.NoError(t, err) {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `jfrog_benchmark_operation_duration_seconds_count{operation="upload",phase="measure"} 2`)
+		assert.Contains(t, string(body), `jfrog_benchmark_operation_duration_seconds_count{operation="upload",phase="warmup"} 1`)
+		assert.Contains(t, string(body), `jfrog_benchmark_transferred_bytes_total{operation="upload",phase="measure"} 2097152`)
+	}
+
+	// The operations aren't recorded once the server is closed.
+	assert.NoError(t, metricsServer.Close())
+	assert.NoError(t, MeasureOperationTimes(config, fileNames[:1], servicesManager, &results))
+	_, err = http.Get("http://" + metricsServer.Addr() + "/metrics")
+	assert.Error(t, err)
+}
+
+func TestValidateMetricsListenInput(t *testing.T) {
+	assert.NoError(t, ValidateMetricsListenInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidateMetricsListenInput(&BenchmarkConfig{MetricsListen: ":9100"}))
+	assert.NoError(t, ValidateMetricsListenInput(&BenchmarkConfig{MetricsListen: "127.0.0.1:9100"}))
+	for _, listen := range []string{"9100", "localhost", "localhost:"} {
+		err := ValidateMetricsListenInput(&BenchmarkConfig{MetricsListen: listen})
+		assert.True(t, err != nil && strings.HasPrefix(err.Error(), "Metrics listen address must be"), listen)
+	}
+}
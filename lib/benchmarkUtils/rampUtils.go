@@ -0,0 +1,176 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The folder inside the repository the uploads of a ramp are written to, every worker uploads to its own sub-folder so
+	// the same artifact is never uploaded concurrently.
+	rampUploadsFolder = "ramp"
+	// The minimal relative throughput gain of a step over the previous one for the throughput to be considered scaling.
+	rampKneeGainThreshold = 0.1
+)
+
+// The throughput and latency of a single concurrency level of a ramp.
+type RampStepResult struct {
+	Concurrency  int
+	Operations   int
+	Duration     time.Duration
+	Throughput   float64
+	MBThroughput float64
+	AvgLatency   time.Duration
+	P50Latency   time.Duration
+	P95Latency   time.Duration
+	P99Latency   time.Duration
+}
+
+// Parses the concurrency levels of a ramp, separated by commas, e.g. "1,2,4,8".
+func ParseRampLevels(ramp string) ([]int, error) {
+	var levels []int
+	for _, level := range strings.Split(ramp, ",") {
+		levelInt, err := strconv.Atoi(strings.TrimSpace(level))
+		if err != nil || levelInt <= 0 {
+			return nil, errors.New("Ramp must be a comma separated list of positive concurrency levels, such as 1,2,4,8")
+		}
+		levels = append(levels, levelInt)
+	}
+	return levels, nil
+}
+
+// Executes the operation of the config with each of the concurrency levels of the Ramp for StepDuration seconds, where
+// every worker repeatedly takes the next one of the provided files, and records the throughput and latency of each level.
+func MeasureRampOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	rampResults *[]RampStepResult) error {
+	for _, level := range st.Ramp {
+		log.Info(fmt.Sprintf("Running %s operations with concurrency of %d for %s", st.Operation, level, st.StepDuration))
+		stepResult, err := runRampStep(st, level, st.StepDuration, fileNames, servicesManager)
+		if err != nil {
+			return err
+		}
+		*rampResults = append(*rampResults, *stepResult)
+	}
+	return nil
+}
+
+func runRampStep(st *BenchmarkConfig, concurrency int, stepDuration time.Duration, fileNames []string,
+	servicesManager artifactory.ArtifactoryServicesManager) (*RampStepResult, error) {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstError error
+	var latencies []time.Duration
+	download := GetDownloadFunc(st)
+	workersServicesManagers, err := newWorkersServicesManagers(servicesManager, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	deadline := start.Add(stepDuration)
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := worker; time.Now().Before(deadline); i += concurrency {
+				file := fileNames[i%len(fileNames)]
+				var duration time.Duration
+				var err error
+				if st.Operation == UploadOperation {
+					duration, err = UploadFilesWithoutChecksumDeploy(file, st.RepositoryName+"/"+rampUploadsFolder+"/"+strconv.Itoa(worker)+"/", workersServicesManagers[worker])
+				} else {
+					duration, err = download(file, st.RepositoryName, workersServicesManagers[worker])
+				}
+				observeFileOperation(st, MeasurePhase, duration, err)
+				mutex.Lock()
+				if err != nil {
+					if firstError == nil {
+						firstError = err
+					}
+					mutex.Unlock()
+					return
+				}
+				latencies = append(latencies, duration)
+				mutex.Unlock()
+			}
+		}(worker)
+	}
+	wg.Wait()
+	if firstError != nil {
+		return nil, firstError
+	}
+	elapsed := time.Since(start)
+	return newRampStepResult(st, concurrency, elapsed, latencies), nil
+}
+
+func newRampStepResult(st *BenchmarkConfig, concurrency int, elapsed time.Duration, latencies []time.Duration) *RampStepResult {
+	result := &RampStepResult{Concurrency: concurrency, Operations: len(latencies), Duration: elapsed}
+	result.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	result.MBThroughput = result.Throughput * float64(st.FilesSizesInMb)
+	if len(latencies) > 0 {
+		var totalLatency time.Duration
+		for _, latency := range latencies {
+			totalLatency += latency
+		}
+		result.AvgLatency = totalLatency / time.Duration(len(latencies))
+	}
+	result.P50Latency = GetPercentile(latencies, 50)
+	result.P95Latency = GetPercentile(latencies, 95)
+	result.P99Latency = GetPercentile(latencies, 99)
+	return result
+}
+
+// Returns the index of the knee point of the ramp, the last step whose throughput is more than 10% higher than the one of
+// the previous step, after which the throughput stops scaling. If the throughput scales up to the last step, the knee
+// point isn't reached and false is returned.
+func GetKneePoint(rampResults []RampStepResult) (int, bool) {
+	for i := 1; i < len(rampResults); i++ {
+		if rampResults[i].Throughput < rampResults[i-1].Throughput*(1+rampKneeGainThreshold) {
+			return i - 1, true
+		}
+	}
+	return len(rampResults) - 1, false
+}
+
+func WriteRampResults(filePath string, metadata *ResultsMetadata, rampResults []RampStepResult) error {
+	file, newFile, err := openResultsFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	if err = writeMetadataHeader(writer, metadata); err != nil {
+		return err
+	}
+	if newFile {
+		fmt.Fprintln(writer, "concurrency,operations,time taken (sec),throughput (operations/sec),throughput (MB/sec),avg latency (sec),p50 latency (sec),p95 latency (sec),p99 latency (sec)")
+	}
+	for _, step := range rampResults {
+		fmt.Fprintf(writer, "%d,%d,%s,%.2f,%.2f,%s,%s,%s,%s\n", step.Concurrency, step.Operations, step.Duration, step.Throughput,
+			step.MBThroughput, step.AvgLatency, step.P50Latency, step.P95Latency, step.P99Latency)
+	}
+	return nil
+}
+
+func PrintKneePoint(rampResults []RampStepResult) {
+	if len(rampResults) == 0 {
+		return
+	}
+	knee, reached := GetKneePoint(rampResults)
+	if !reached {
+		fmt.Printf("The throughput kept scaling up to the concurrency of %d (%.2f operations/sec), the knee point wasn't reached\n",
+			rampResults[knee].Concurrency, rampResults[knee].Throughput)
+		return
+	}
+	fmt.Printf("Knee point: the throughput stops scaling after the concurrency of %d (%.2f operations/sec)\n",
+		rampResults[knee].Concurrency, rampResults[knee].Throughput)
+}
@@ -0,0 +1,100 @@
+package benchmarkUtils
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRampLevels(t *testing.T) {
+	levels, err := ParseRampLevels("1,2, 4,8")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 4, 8}, levels)
+	for _, ramp := range []string{"", "1,,2", "1,0", "1,two"} {
+		_, err = ParseRampLevels(ramp)
+		assert.Error(t, err, "Expected an error for the ramp %q", ramp)
+	}
+}
+
+func TestGetKneePoint(t *testing.T) {
+	steps := []RampStepResult{{Concurrency: 1, Throughput: 10}, {Concurrency: 2, Throughput: 19}, {Concurrency: 4, Throughput: 30},
+		{Concurrency: 8, Throughput: 32}, {Concurrency: 16, Throughput: 40}}
+	knee, reached := GetKneePoint(steps)
+	assert.True(t, reached)
+	assert.Equal(t, 4, steps[knee].Concurrency)
+
+	knee, reached = GetKneePoint(steps[:3])
+	assert.False(t, reached)
+	assert.Equal(t, 2, knee)
+}
+
+func TestRunRampStep(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 3)
+	defer os.RemoveAll(localDir)
+	// Large enough to be deployed by its checksum once it was uploaded, if the checksum deploy wasn't disabled.
+	assert.NoError(t, ioutil.WriteFile(fileNames[0], make([]byte, 20*1024), os.ModePerm))
+
+	config := &BenchmarkConfig{RepositoryName: "benchmark-up-tests", FilesSizesInMb: 2, Operation: "upload"}
+	step, err := runRampStep(config, 2, 200*time.Millisecond, fileNames, servicesManager)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2, step.Concurrency)
+	assert.Equal(t, len(fake.uploaded), step.Operations)
+	assert.True(t, step.Operations > 0)
+	assert.True(t, step.Duration >= 200*time.Millisecond)
+	assert.InDelta(t, step.Throughput*2, step.MBThroughput, 0.001)
+	assert.True(t, step.P50Latency <= step.P99Latency)
+	assert.Empty(t, fake.checksumDeploys)
+	for _, uploaded := range fake.uploaded {
+		assert.True(t, strings.HasPrefix(uploaded, "benchmark-up-tests/ramp/0/") || strings.HasPrefix(uploaded, "benchmark-up-tests/ramp/1/"),
+			"Unexpected upload path %s", uploaded)
+	}
+}
+
+func TestMeasureRampOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-dl-tests/File1.txt": []byte("first artifact"),
+	})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+
+	var results []RampStepResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-dl-tests", FilesSizesInMb: 1, Operation: "download", Ramp: []int{2}, StepDuration: time.Second}
+	metrics := NewLiveMetrics()
+	addOperationObserver(metrics)
+	defer removeOperationObserver(metrics)
+	assert.NoError(t, MeasureRampOperationTimes(config, []string{"/tmp/testfiles/File1.txt"}, servicesManager, &results))
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, 2, results[0].Concurrency)
+		assert.Equal(t, len(fake.downloaded), results[0].Operations)
+	}
+	// The operations of the ramp are recorded in the live metrics.
+	var buffer bytes.Buffer
+	assert.NoError(t, metrics.Write(&buffer))
+	assert.Contains(t, buffer.String(), fmt.Sprintf(`jfrog_benchmark_operation_duration_seconds_count{operation="download",phase="measure"} %d`, len(fake.downloaded)))
+
+	// A failing operation fails the ramp.
+	assert.Error(t, MeasureRampOperationTimes(config, []string{"/tmp/testfiles/File2.txt"}, servicesManager, &results))
+}
+
+func TestWriteRampResults(t *testing.T) {
+	filePath := "ramp-results.csv"
+	defer os.Remove(filePath)
+	steps := []RampStepResult{{Concurrency: 4, Operations: 20, Duration: 2 * time.Second, Throughput: 10, MBThroughput: 50,
+		AvgLatency: 400 * time.Millisecond, P50Latency: 350 * time.Millisecond, P95Latency: 700 * time.Millisecond, P99Latency: time.Second}}
+	assert.NoError(t, WriteRampResults(filePath, nil, steps))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "concurrency,operations,time taken (sec),throughput (operations/sec),throughput (MB/sec),avg latency (sec),p50 latency (sec),p95 latency (sec),p99 latency (sec)\n"+
+		"4,20,2s,10.00,50.00,400ms,350ms,700ms,1s\n", string(content))
+}
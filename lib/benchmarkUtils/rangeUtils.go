@@ -0,0 +1,118 @@
+package benchmarkUtils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	mathRand "math/rand"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The patterns of the offsets of the range requests sent for each file.
+const (
+	SequentialRanges = "sequential"
+	RandomRanges     = "random"
+)
+
+// A range of bytes in a file, starting at the offset.
+type ByteRange struct {
+	Offset int64
+	Length int64
+}
+
+func (r ByteRange) String() string {
+	return fmt.Sprintf("bytes=%d-%d", r.Offset, r.Offset+r.Length-1)
+}
+
+// Returns the given number of ranges of rangeSize bytes in a file of fileSize bytes. Sequential ranges follow each other
+// from the start of the file and wrap around at its end, random ranges start at random offsets. A range is shortened if
+// it would exceed the end of the file.
+func GetByteRanges(fileSize int64, rangeSize int64, count int, pattern string) []ByteRange {
+	ranges := make([]ByteRange, count)
+	for i := range ranges {
+		var offset int64
+		if pattern == RandomRanges {
+			if fileSize > rangeSize {
+				offset = mathRand.Int63n(fileSize - rangeSize + 1)
+			}
+		} else {
+			rangesInFile := (fileSize + rangeSize - 1) / rangeSize
+			offset = (int64(i) % rangesInFile) * rangeSize
+		}
+		length := rangeSize
+		if offset+length > fileSize {
+			length = fileSize - offset
+		}
+		ranges[i] = ByteRange{Offset: offset, Length: length}
+	}
+	return ranges
+}
+
+// Downloads the range of the artifact with a single range request and returns the time it took, including reading the
+// returned bytes.
+func DownloadRange(fileName string, repositoryName string, byteRange ByteRange, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, []byte, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	utils.AddHeader("Range", byteRange.String(), &httpClientsDetails.Headers)
+	start := time.Now()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+GetArtifactPath(repositoryName, fileName), true, &httpClientsDetails)
+	end := time.Since(start)
+	if err != nil {
+		return 0, nil, err
+	}
+	err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusPartialContent)
+	if err != nil {
+		return 0, nil, err
+	}
+	return end, body, nil
+}
+
+// Sends Repeats range requests of RangeSize KB for each of the provided files, which should already be uploaded to the
+// repository, at the offsets of the configured pattern. The returned bytes are compared with the local files.
+func MeasureRangeOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	for _, file := range fileNames {
+		err := measureFileRanges(st, file, servicesManager, benchmarkResults)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func measureFileRanges(st *BenchmarkConfig, file string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	localFile, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+	info, err := localFile.Stat()
+	if err != nil {
+		return err
+	}
+	artifactPath := GetArtifactPath(st.RepositoryName, file)
+	for _, byteRange := range GetByteRanges(info.Size(), int64(st.RangeSize)*1024, st.Repeats, st.RangePattern) {
+		duration, data, err := DownloadRange(file, st.RepositoryName, byteRange, servicesManager)
+		if err != nil {
+			return err
+		}
+		expected := make([]byte, byteRange.Length)
+		_, err = localFile.ReadAt(expected, byteRange.Offset)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(expected, data) {
+			return errors.New("The bytes returned for [" + byteRange.String() + "] of [" + artifactPath + "] don't match the uploaded file")
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewKBBenchmarkResult("range-"+st.RangePattern, artifactPath+" "+byteRange.String(),
+			float64(byteRange.Length)/1024, duration))
+	}
+	return nil
+}
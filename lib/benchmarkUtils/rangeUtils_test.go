@@ -0,0 +1,48 @@
+package benchmarkUtils
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByteRanges(t *testing.T) {
+	assert.Equal(t, []ByteRange{{0, 4}, {4, 4}, {8, 2}, {0, 4}}, GetByteRanges(10, 4, 4, SequentialRanges))
+	assert.Equal(t, "bytes=4-7", ByteRange{4, 4}.String())
+	for _, byteRange := range GetByteRanges(10, 4, 20, RandomRanges) {
+		assert.True(t, byteRange.Offset >= 0 && byteRange.Offset <= 6, "Unexpected offset %d", byteRange.Offset)
+		assert.Equal(t, int64(4), byteRange.Length)
+	}
+	// A range larger than the file is shortened to the whole file.
+	assert.Equal(t, []ByteRange{{0, 10}}, GetByteRanges(10, 20, 1, RandomRanges))
+}
+
+func TestMeasureRangeOperationTimes(t *testing.T) {
+	localDir, fileNames := createLocalTestFiles(t, 1)
+	defer os.RemoveAll(localDir)
+	data := make([]byte, 3000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	assert.NoError(t, ioutil.WriteFile(fileNames[0], data, os.ModePerm))
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{"benchmark-range-tests/File1.txt": data})
+	defer fake.Close()
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-range-tests", RangeSize: 1, Repeats: 3, RangePattern: SequentialRanges}
+	assert.NoError(t, MeasureRangeOperationTimes(config, fileNames, servicesManager, &results))
+	assert.Equal(t, 3, fake.rangeRequests)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "range-sequential", results[0].Operation)
+		assert.Equal(t, "benchmark-range-tests/File1.txt bytes=0-1023", results[0].FileName)
+		assert.Equal(t, "1", results[0].Size)
+		assert.Equal(t, "benchmark-range-tests/File1.txt bytes=2048-2999", results[2].FileName)
+	}
+
+	// The artifact doesn't match the local file anymore.
+	fake.artifacts["benchmark-range-tests/File1.txt"] = make([]byte, 3000)
+	config.RangePattern = RandomRanges
+	assert.Error(t, MeasureRangeOperationTimes(config, fileNames, servicesManager, &results))
+}
@@ -0,0 +1,180 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The result of an operation issued at a fixed arrival rate. The queue delay is the time the operation waited for a free
+// worker after its scheduled start, and the latency is the time from its scheduled start to its completion.
+type RateBenchmarkResult struct {
+	BenchmarkResult
+	QueueDelay time.Duration
+	Latency    time.Duration
+}
+
+// Aggregated latencies and queue delays of a single operation type issued at a fixed arrival rate.
+type RateOperationStats struct {
+	Operation     string
+	Count         int
+	P50Latency    time.Duration
+	P90Latency    time.Duration
+	P99Latency    time.Duration
+	MaxLatency    time.Duration
+	AvgQueueDelay time.Duration
+	MaxQueueDelay time.Duration
+}
+
+type scheduledOperation struct {
+	index     int
+	scheduled time.Time
+}
+
+// Issues an operation on each of the provided files at the Rate of the config (operations per second), regardless of the
+// completion of the previous operations. The operations are executed by Concurrency workers, an operation whose scheduled
+// start arrives while all the workers are busy waits in a queue, and the wait is recorded as its queue delay.
+func MeasureRateOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]RateBenchmarkResult) error {
+	operation := UploadFilesWithoutChecksumDeploy
+	if st.Operation == DownloadOperation {
+		operation = GetDownloadFunc(st)
+	}
+	interval := time.Duration(float64(time.Second) / st.Rate)
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstError error
+	workersServicesManagers, err := newWorkersServicesManagers(servicesManager, st.Concurrency)
+	if err != nil {
+		return err
+	}
+	// The queue can hold all the operations, so the schedule never waits for the workers.
+	queue := make(chan scheduledOperation, len(fileNames))
+	for worker := 0; worker < st.Concurrency; worker++ {
+		wg.Add(1)
+		go func(workerServicesManager artifactory.ArtifactoryServicesManager) {
+			defer wg.Done()
+			for op := range queue {
+				queueDelay := time.Since(op.scheduled)
+				var result []BenchmarkResult
+				err := MeasureSingleOperation(fileNames[op.index], st, workerServicesManager, &result, operation)
+				mutex.Lock()
+				if err != nil && firstError == nil {
+					firstError = err
+				}
+				if err == nil {
+					duration, _ := time.ParseDuration(result[0].Duration)
+					result[0].Operation = string(st.Operation)
+					*benchmarkResults = append(*benchmarkResults, RateBenchmarkResult{BenchmarkResult: result[0], QueueDelay: queueDelay,
+						Latency: queueDelay + duration})
+				}
+				mutex.Unlock()
+			}
+		}(workersServicesManagers[worker])
+	}
+	start := time.Now()
+	for i := range fileNames {
+		scheduled := start.Add(time.Duration(i) * interval)
+		time.Sleep(time.Until(scheduled))
+		queue <- scheduledOperation{index: i, scheduled: scheduled}
+	}
+	close(queue)
+	wg.Wait()
+	achievedRate := float64(len(*benchmarkResults)) / time.Since(start).Seconds()
+	log.Info(fmt.Sprintf("Requested rate of %g operations/sec, achieved %.2f operations/sec", st.Rate, achievedRate))
+	return firstError
+}
+
+// Writes the results the same way as WriteOperationResults, with the queue delay and latency of each result.
+func WriteRateResults(filePath string, metadata *ResultsMetadata, results []RateBenchmarkResult) error {
+	file, newFile, err := openResultsFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	if err = writeMetadataHeader(writer, metadata); err != nil {
+		return err
+	}
+	if newFile {
+		fmt.Fprintln(writer, "operation,file,size (MB),queue delay (sec),time taken (sec),latency (sec),speed (MB/sec)")
+	}
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s,%s,%s,%s,%s,%s,%s\n", result.Operation, csvField(result.FileName), result.Size, result.QueueDelay,
+			result.Duration, result.Latency, result.Speed)
+	}
+	return nil
+}
+
+// Groups the results by their operation and calculates the latency percentiles and queue delays of each group.
+func GetRateOperationStats(results []RateBenchmarkResult) ([]RateOperationStats, error) {
+	if len(results) == 0 {
+		return nil, errors.New("No results to calculate the stats of")
+	}
+	var operations []string
+	latencies := map[string][]time.Duration{}
+	queueDelays := map[string][]time.Duration{}
+	for _, result := range results {
+		if _, ok := latencies[result.Operation]; !ok {
+			operations = append(operations, result.Operation)
+		}
+		latencies[result.Operation] = append(latencies[result.Operation], result.Latency)
+		queueDelays[result.Operation] = append(queueDelays[result.Operation], result.QueueDelay)
+	}
+	var stats []RateOperationStats
+	for _, operation := range operations {
+		var totalQueueDelay time.Duration
+		for _, queueDelay := range queueDelays[operation] {
+			totalQueueDelay += queueDelay
+		}
+		count := len(latencies[operation])
+		stats = append(stats, RateOperationStats{
+			Operation:     operation,
+			Count:         count,
+			P50Latency:    GetPercentile(latencies[operation], 50),
+			P90Latency:    GetPercentile(latencies[operation], 90),
+			P99Latency:    GetPercentile(latencies[operation], 99),
+			MaxLatency:    GetPercentile(latencies[operation], 100),
+			AvgQueueDelay: totalQueueDelay / time.Duration(count),
+			MaxQueueDelay: GetPercentile(queueDelays[operation], 100),
+		})
+	}
+	return stats, nil
+}
+
+// Returns the percentile of the durations using the nearest-rank method.
+func GetPercentile(durations []time.Duration, percentile float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func PrintRateOperationStats(results []RateBenchmarkResult) error {
+	stats, err := GetRateOperationStats(results)
+	if err != nil {
+		return err
+	}
+	fmt.Println("operation,count,p50 latency (sec),p90 latency (sec),p99 latency (sec),max latency (sec),avg queue delay (sec),max queue delay (sec)")
+	for _, stat := range stats {
+		fmt.Printf("%s,%d,%s,%s,%s,%s,%s,%s\n", stat.Operation, stat.Count, stat.P50Latency, stat.P90Latency, stat.P99Latency,
+			stat.MaxLatency, stat.AvgQueueDelay, stat.MaxQueueDelay)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package benchmarkUtils
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPercentile(t *testing.T) {
+	durations := []time.Duration{5 * time.Second, 1 * time.Second, 4 * time.Second, 2 * time.Second, 3 * time.Second}
+	assert.Equal(t, 3*time.Second, GetPercentile(durations, 50))
+	assert.Equal(t, 5*time.Second, GetPercentile(durations, 90))
+	assert.Equal(t, 1*time.Second, GetPercentile(durations, 0))
+	assert.Equal(t, 5*time.Second, GetPercentile(durations, 100))
+	assert.Equal(t, 5*time.Second, durations[0], "Expected the durations not to be sorted in place")
+	assert.Equal(t, time.Duration(0), GetPercentile(nil, 50))
+}
+
+func TestGetRateOperationStats(t *testing.T) {
+	results := []RateBenchmarkResult{
+		{BenchmarkResult: BenchmarkResult{Operation: "upload"}, QueueDelay: 0, Latency: time.Second},
+		{BenchmarkResult: BenchmarkResult{Operation: "upload"}, QueueDelay: 2 * time.Second, Latency: 3 * time.Second},
+		{BenchmarkResult: BenchmarkResult{Operation: "download"}, QueueDelay: time.Second, Latency: 2 * time.Second},
+	}
+	stats, err := GetRateOperationStats(results)
+	assert.NoError(t, err)
+	assert.Equal(t, []RateOperationStats{
+		{Operation: "upload", Count: 2, P50Latency: time.Second, P90Latency: 3 * time.Second, P99Latency: 3 * time.Second,
+			MaxLatency: 3 * time.Second, AvgQueueDelay: time.Second, MaxQueueDelay: 2 * time.Second},
+		{Operation: "download", Count: 1, P50Latency: 2 * time.Second, P90Latency: 2 * time.Second, P99Latency: 2 * time.Second,
+			MaxLatency: 2 * time.Second, AvgQueueDelay: time.Second, MaxQueueDelay: time.Second},
+	}, stats)
+
+	_, err = GetRateOperationStats(nil)
+	assert.Error(t, err)
+}
+
+func TestWriteRateResults(t *testing.T) {
+	filePath := "rate-results.csv"
+	defer os.Remove(filePath)
+	results := []RateBenchmarkResult{
+		{BenchmarkResult: BenchmarkResult{"file1.dat", "1", "1s", "1.00", "upload"}, QueueDelay: 500 * time.Millisecond, Latency: 1500 * time.Millisecond},
+	}
+	assert.NoError(t, WriteRateResults(filePath, nil, results))
+	assert.NoError(t, WriteRateResults(filePath, nil, results))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "operation,file,size (MB),queue delay (sec),time taken (sec),latency (sec),speed (MB/sec)\n"+
+		"upload,file1.dat,1,500ms,1s,1.5s,1.00\n"+
+		"upload,file1.dat,1,500ms,1s,1.5s,1.00\n", string(content))
+}
+
+func TestMeasureRateOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 5)
+	defer os.RemoveAll(localDir)
+
+	var results []RateBenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-up-tests", FilesSizesInMb: 1, Operation: "upload", Rate: 20, Concurrency: 2}
+	start := time.Now()
+	assert.NoError(t, MeasureRateOperationTimes(config, fileNames, servicesManager, &results))
+	// The last operation is scheduled 4 intervals of 50ms after the first one.
+	assert.True(t, time.Since(start) >= 200*time.Millisecond, "Expected the operations to be issued at the requested rate")
+	assert.Len(t, results, 5)
+	assert.Len(t, fake.uploaded, 5)
+	for _, result := range results {
+		assert.Equal(t, "upload", result.Operation)
+		duration, err := time.ParseDuration(result.Duration)
+		assert.NoError(t, err)
+		assert.Equal(t, result.QueueDelay+duration, result.Latency)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,10 +18,45 @@ type BenchMarkResults struct {
 }
 
 type BenchmarkResult struct {
-	FileName string
-	Size     string
-	Duration string
-	Speed    string
+	FileName  string
+	Size      string
+	Duration  string
+	Speed     string
+	Operation string
+}
+
+// Returns the result of an operation on items, its size is the number of items and its speed is in items per second.
+func NewItemsBenchmarkResult(operation string, path string, items int, duration time.Duration) *BenchmarkResult {
+	speed := float64(items) / duration.Seconds()
+	result := NewBenchmarkResult(path, strconv.Itoa(items), fmt.Sprintf("%s", duration), fmt.Sprintf("%.2f", speed))
+	result.Operation = operation
+	return result
+}
+
+// Returns the result of an operation on the given size of data, its speed is in MB per second.
+func NewMBBenchmarkResult(operation string, path string, sizeInMb int, duration time.Duration) *BenchmarkResult {
+	speed := float64(sizeInMb) / duration.Seconds()
+	result := NewBenchmarkResult(path, strconv.Itoa(sizeInMb), fmt.Sprintf("%s", duration), fmt.Sprintf("%.2f", speed))
+	result.Operation = operation
+	return result
+}
+
+// Returns the result of an operation on the given size of data in KB, its speed is in KB per second.
+func NewKBBenchmarkResult(operation string, path string, sizeInKb float64, duration time.Duration) *BenchmarkResult {
+	speed := sizeInKb / duration.Seconds()
+	result := NewBenchmarkResult(path, strconv.FormatFloat(sizeInKb, 'f', -1, 64), fmt.Sprintf("%s", duration), fmt.Sprintf("%.2f", speed))
+	result.Operation = operation
+	return result
+}
+
+// Aggregated results of a single operation type
+type OperationStats struct {
+	Operation   string
+	Count       int
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	AvgDuration time.Duration
+	AvgSpeed    float64
 }
 
 func NewBenchMarkResults(results []BenchmarkResult) *BenchMarkResults {
@@ -30,35 +67,137 @@ func NewBenchmarkResult(file string, size string, duration string, speed string)
 	return &BenchmarkResult{FileName: file, Size: size, Duration: duration, Speed: speed}
 }
 
-func WriteResults(filePath string, results []BenchmarkResult) error {
-	finalResults := NewBenchMarkResults(results)
-	var err error
-	var file *os.File
-	var writer *bufio.Writer
+func WriteResults(filePath string, metadata *ResultsMetadata, results []BenchmarkResult) error {
+	file, newFile, err := openResultsFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
 
-	if _, err = os.Stat(filePath); os.IsNotExist(err) {
-		file, err := os.Create(filePath)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	if err = writeMetadataHeader(writer, metadata); err != nil {
+		return err
+	}
+	if newFile {
+		fmt.Fprintln(writer, NewBenchMarkResults(results).ColumnNames)
+	}
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s,%s,%s,%s\n", result.FileName, result.Size, result.Duration, result.Speed)
+	}
+	return nil
+}
+
+// Writes the results the same way as WriteResults, with an additional leading column for the operation of each result.
+func WriteOperationResults(filePath string, metadata *ResultsMetadata, results []BenchmarkResult) error {
+	return writeOperationResults(filePath, metadata, "operation,"+NewBenchMarkResults(results).ColumnNames, results)
+}
+
+// Writes the results of operations that are measured by the number of items they handle rather than by their size,
+// the size of these results is the number of items and their speed is in items per second.
+func WriteItemsOperationResults(filePath string, metadata *ResultsMetadata, results []BenchmarkResult) error {
+	return writeOperationResults(filePath, metadata, "operation,path,items,time taken (sec),speed (items/sec)", results)
+}
+
+// Writes the results of operations on small parts of files, the size of these results is in KB and their speed is in KB
+// per second.
+func WriteKBOperationResults(filePath string, metadata *ResultsMetadata, results []BenchmarkResult) error {
+	return writeOperationResults(filePath, metadata, "operation,path,size (KB),time taken (sec),speed (KB/sec)", results)
+}
+
+func writeOperationResults(filePath string, metadata *ResultsMetadata, columnNames string, results []BenchmarkResult) error {
+	file, newFile, err := openResultsFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
 
-		writer = bufio.NewWriter(file)
-		defer writer.Flush()
-		fmt.Fprintln(writer, finalResults.ColumnNames)
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	if err = writeMetadataHeader(writer, metadata); err != nil {
+		return err
+	}
+	if newFile {
+		fmt.Fprintln(writer, columnNames)
+	}
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s,%s,%s,%s,%s\n", result.Operation, csvField(result.FileName), result.Size, result.Duration, result.Speed)
+	}
+	return nil
+}
+
+// Opens the results file for appending, or creates it if it doesn't exist, in which case the returned bool is true and
+// the column names should be written first.
+func openResultsFile(filePath string) (*os.File, bool, error) {
+	var file *os.File
+	var err error
+	_, statErr := os.Stat(filePath)
+	newFile := os.IsNotExist(statErr)
+	if newFile {
+		file, err = os.Create(filePath)
 	} else { // if file already exists append the results to it
 		file, err = os.OpenFile(filePath, os.O_WRONLY|os.O_APPEND, 0644)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
+	}
+	return file, newFile, err
+}
 
-		writer = bufio.NewWriter(file)
-		defer writer.Flush()
+// Quotes the field if it contains a comma or quotes, as search queries do.
+func csvField(field string) string {
+	if strings.ContainsAny(field, ",\"\n") {
+		return `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
 	}
+	return field
+}
 
+// Groups the results by their operation and aggregates the durations and speeds of each group.
+func GetOperationStats(results []BenchmarkResult) ([]OperationStats, error) {
+	var stats []OperationStats
+	indexes := map[string]int{}
+	var totalDurations []time.Duration
+	var totalSpeeds []float64
 	for _, result := range results {
-		fmt.Fprintf(writer, "%s,%s,%s,%s\n", result.FileName, result.Size, result.Duration, result.Speed)
+		duration, err := time.ParseDuration(result.Duration)
+		if err != nil {
+			return nil, err
+		}
+		speed, err := strconv.ParseFloat(result.Speed, 64)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := indexes[result.Operation]
+		if !ok {
+			i = len(stats)
+			indexes[result.Operation] = i
+			stats = append(stats, OperationStats{Operation: result.Operation, MinDuration: duration, MaxDuration: duration})
+			totalDurations = append(totalDurations, 0)
+			totalSpeeds = append(totalSpeeds, 0)
+		}
+		stats[i].Count++
+		if duration < stats[i].MinDuration {
+			stats[i].MinDuration = duration
+		}
+		if duration > stats[i].MaxDuration {
+			stats[i].MaxDuration = duration
+		}
+		totalDurations[i] += duration
+		totalSpeeds[i] += speed
+	}
+	for i := range stats {
+		stats[i].AvgDuration = totalDurations[i] / time.Duration(stats[i].Count)
+		stats[i].AvgSpeed = totalSpeeds[i] / float64(stats[i].Count)
+	}
+	return stats, nil
+}
+
+// Prints the stats of each operation type, the speed unit is either MB, KB or items according to the written results.
+func PrintOperationStats(results []BenchmarkResult, speedUnit string) error {
+	stats, err := GetOperationStats(results)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("operation,count,min time (sec),max time (sec),avg time (sec),avg speed (%s/sec)\n", speedUnit)
+	for _, stat := range stats {
+		fmt.Printf("%s,%d,%s,%s,%s,%.2f\n", stat.Operation, stat.Count, stat.MinDuration, stat.MaxDuration, stat.AvgDuration, stat.AvgSpeed)
 	}
 	return nil
 }
@@ -2,8 +2,12 @@ package benchmarkUtils
 
 import (
 	"bufio"
+	"io/ioutil"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewBenchmarkResult(t *testing.T) {
@@ -35,12 +39,12 @@ func TestWriteResults(t *testing.T) {
 	// Define test inputs
 	filePath := "results.csv"
 	results := []BenchmarkResult{
-		{"file1.dat", "1", "1.23", "800"},
-		{"file2.dat", "2", "2.34", "900"},
+		{"file1.dat", "1", "1.23", "800", ""},
+		{"file2.dat", "2", "2.34", "900", ""},
 	}
 
 	// Call the function being tested
-	err := WriteResults(filePath, results)
+	err := WriteResults(filePath, nil, results)
 
 	// Check if there is no error
 	if err != nil {
@@ -84,3 +88,77 @@ func TestWriteResults(t *testing.T) {
 		t.Errorf("not able to delete the csv file")
 	}
 }
+
+func TestGetOperationStats(t *testing.T) {
+	results := []BenchmarkResult{
+		{"file1.dat", "1", "1s", "1.00", "download"},
+		{"file2.dat", "1", "3s", "0.33", "download"},
+		{"file1.dat", "1", "2s", "0.50", "upload"},
+	}
+	stats, err := GetOperationStats(results)
+	assert.NoError(t, err)
+	assert.Equal(t, []OperationStats{
+		{Operation: "download", Count: 2, MinDuration: time.Second, MaxDuration: 3 * time.Second, AvgDuration: 2 * time.Second, AvgSpeed: 0.665},
+		{Operation: "upload", Count: 1, MinDuration: 2 * time.Second, MaxDuration: 2 * time.Second, AvgDuration: 2 * time.Second, AvgSpeed: 0.5},
+	}, stats)
+
+	_, err = GetOperationStats([]BenchmarkResult{{"file1.dat", "1", "not a duration", "1.00", "upload"}})
+	assert.Error(t, err)
+}
+
+func TestWriteOperationResults(t *testing.T) {
+	filePath := "operation-results.csv"
+	defer os.Remove(filePath)
+	results := []BenchmarkResult{
+		{"file1.dat", "1", "1.23", "800", "download"},
+		{"file2.dat", "2", "2.34", "900", "upload"},
+	}
+	assert.NoError(t, WriteOperationResults(filePath, nil, results))
+	// Writing to an existing file appends the results without repeating the header.
+	assert.NoError(t, WriteOperationResults(filePath, nil, results[:1]))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "operation,file,size (MB),time taken (sec),speed (MB/sec)\n"+
+		"download,file1.dat,1,1.23,800\n"+
+		"upload,file2.dat,2,2.34,900\n"+
+		"download,file1.dat,1,1.23,800\n", string(content))
+}
+
+func TestNewItemsBenchmarkResult(t *testing.T) {
+	result := NewItemsBenchmarkResult("aql", "items.find({})", 10, 2*time.Second)
+	assert.Equal(t, BenchmarkResult{FileName: "items.find({})", Size: "10", Duration: "2s", Speed: "5.00", Operation: "aql"}, *result)
+}
+
+func TestWriteItemsOperationResults(t *testing.T) {
+	filePath := "items-results.csv"
+	defer os.Remove(filePath)
+	results := []BenchmarkResult{
+		{`items.find({"repo":"r","name":"a"})`, "3", "1s", "3.00", "aql"},
+		{"r/*", "4", "2s", "2.00", "pattern"},
+	}
+	assert.NoError(t, WriteItemsOperationResults(filePath, nil, results))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "operation,path,items,time taken (sec),speed (items/sec)\n"+
+		`aql,"items.find({""repo"":""r"",""name"":""a""})",3,1s,3.00`+"\n"+
+		"pattern,r/*,4,2s,2.00\n", string(content))
+}
+
+func TestNewKBBenchmarkResult(t *testing.T) {
+	result := NewKBBenchmarkResult("range-sequential", "r/File1.txt bytes=0-511", 0.5, time.Second/2)
+	assert.Equal(t, BenchmarkResult{FileName: "r/File1.txt bytes=0-511", Size: "0.5", Duration: "500ms", Speed: "1.00", Operation: "range-sequential"}, *result)
+}
+
+func TestWriteKBOperationResults(t *testing.T) {
+	filePath := "kb-results.csv"
+	defer os.Remove(filePath)
+	results := []BenchmarkResult{{"r/File1.txt bytes=0-1023", "1", "1s", "1.00", "range-random"}}
+	assert.NoError(t, WriteKBOperationResults(filePath, nil, results))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "operation,path,size (KB),time taken (sec),speed (KB/sec)\n"+
+		"range-random,r/File1.txt bytes=0-1023,1,1s,1.00\n", string(content))
+}
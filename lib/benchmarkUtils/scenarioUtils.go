@@ -0,0 +1,533 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// The version of the scenario file format, files of other versions are rejected so their meaning never changes silently.
+const ScenarioVersion = 1
+
+const (
+	defaultScenarioName       = "scenario"
+	defaultScenarioRepository = "benchmark-scenario-tests"
+)
+
+// The phases names are used as folders in the repository.
+var scenarioPhaseNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9-._]+$`)
+
+// The operations a phase of a scenario can measure.
+var scenarioOperations = []string{"upload", "download", "search", "delete"}
+
+// The roles of the phases. Setup phases run before all the others and teardown phases after them, even if a previous
+// phase failed, and both are not measured. Warm-up phases are measured, but excluded from the statistics.
+const (
+	scenarioRoleSetup    = "setup"
+	scenarioRoleWarmup   = "warmup"
+	scenarioRoleMeasure  = "measure"
+	scenarioRoleTeardown = "teardown"
+)
+
+var scenarioRoles = []string{scenarioRoleSetup, scenarioRoleWarmup, scenarioRoleMeasure, scenarioRoleTeardown}
+
+// A benchmark run described in a YAML or JSON file, its phases are executed one after the other on the same repository.
+type Scenario struct {
+	Version    int             `yaml:"version" json:"version"`
+	Name       string          `yaml:"name" json:"name"`
+	Server     ScenarioServer  `yaml:"server" json:"server"`
+	Repository string          `yaml:"repository" json:"repository"`
+	Output     ScenarioOutput  `yaml:"output" json:"output"`
+	Phases     []ScenarioPhase `yaml:"phases" json:"phases"`
+}
+
+// The server the scenario is executed on, either the ID of a configured server or its url, username and password. The
+// default configured server is used if none of the fields are set. The password can be left out of the file, which is
+// meant to be checked in, and set by the JFROG_BENCHMARK_PASSWORD environment variable instead.
+type ScenarioServer struct {
+	Id       string `yaml:"id" json:"id"`
+	Url      string `yaml:"url" json:"url"`
+	Username string `yaml:"username" json:"username"`
+	Password string `yaml:"password" json:"password"`
+}
+
+// The results file of the scenario, the results are appended to it if it already exists.
+type ScenarioOutput struct {
+	File string `yaml:"file" json:"file"`
+}
+
+type ScenarioPhase struct {
+	Name      string `yaml:"name" json:"name"`
+	Operation string `yaml:"operation" json:"operation"`
+	Role      string `yaml:"role" json:"role"`
+	// The size (in MB) and the number of the files generated for the upload, download and delete operations.
+	Size  int `yaml:"size" json:"size"`
+	Files int `yaml:"files" json:"files"`
+	// The name of a previous upload phase, the download or delete operation uses its files instead of uploading its own.
+	Source string `yaml:"source" json:"source"`
+	// How many operations are executed at the same time.
+	Concurrency int `yaml:"concurrency" json:"concurrency"`
+	// The stop conditions of the uploads and downloads, if one of them is set the operation is repeated over the files
+	// for this number of seconds or this number of operations, instead of once per file.
+	Duration   int `yaml:"duration" json:"duration"`
+	Operations int `yaml:"operations" json:"operations"`
+	// The AQL queries and search patterns of the search operation, they may contain the {repo} placeholder.
+	Queries  []string `yaml:"queries" json:"queries"`
+	Patterns []string `yaml:"patterns" json:"patterns"`
+	Repeats  int      `yaml:"repeats" json:"repeats"`
+}
+
+// The result of an operation of a scenario, tagged with the phase it was measured in. The unit is the unit of the size,
+// either MB or items.
+type ScenarioResult struct {
+	BenchmarkResult
+	Phase string
+	// The role of the phase, the results of the warm-up phases are written along with the measured ones.
+	Role string
+	Unit string
+}
+
+// Reads the scenario file, either YAML (.yaml or .yml) or JSON (.json), sets the defaults of the missing fields and
+// validates it. Unknown fields are rejected so misspelled settings don't silently fall back to their defaults.
+func LoadScenario(filePath string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.New("Failed to read the scenario file [" + filePath + "] - " + err.Error())
+	}
+	scenario := new(Scenario)
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(scenario)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(scenario)
+	default:
+		return nil, errors.New("The scenario file [" + filePath + "] must have a .yaml, .yml or .json extension")
+	}
+	if err != nil {
+		return nil, errors.New("Failed to parse the scenario file [" + filePath + "] - " + err.Error())
+	}
+	scenario.setDefaults()
+	err = ValidateScenario(scenario)
+	if err != nil {
+		return nil, errors.New("Invalid scenario file [" + filePath + "] - " + err.Error())
+	}
+	return scenario, nil
+}
+
+func (scenario *Scenario) setDefaults() {
+	if scenario.Name == "" {
+		scenario.Name = defaultScenarioName
+	}
+	if scenario.Repository == "" {
+		scenario.Repository = defaultScenarioRepository
+	}
+	if scenario.Server.Url != "" && scenario.Server.Password == "" {
+		scenario.Server.Password = os.Getenv(GetEnvVarName("password"))
+	}
+	for i := range scenario.Phases {
+		if scenario.Phases[i].Role == "" {
+			scenario.Phases[i].Role = scenarioRoleMeasure
+		}
+		if scenario.Phases[i].Concurrency == 0 {
+			scenario.Phases[i].Concurrency = 1
+		}
+		if scenario.Phases[i].Repeats == 0 {
+			scenario.Phases[i].Repeats = 1
+		}
+	}
+}
+
+// Returns the config of the server and repository of the scenario, the same as the flags of the other commands set it.
+func (scenario *Scenario) GetBenchmarkConfig() *BenchmarkConfig {
+	config := NewBenchmarkConfig(ScenarioOperation)
+	config.RepositoryName = scenario.Repository
+	config.Url = scenario.Server.Url
+	config.UserName = scenario.Server.Username
+	config.Password = scenario.Server.Password
+	config.ServerId = scenario.Server.Id
+	config.Iterations = scenario.getMaxFiles()
+	return config
+}
+
+// Returns the highest number of files generated by a phase, all the generated files are named File1.txt to FileN.txt.
+func (scenario *Scenario) getMaxFiles() int {
+	maxFiles := 0
+	for _, phase := range scenario.Phases {
+		if phase.Operation != "search" && phase.Files > maxFiles {
+			maxFiles = phase.Files
+		}
+	}
+	return maxFiles
+}
+
+// Validates the scenario after its defaults are set, the errors name the phase they were found in.
+func ValidateScenario(scenario *Scenario) error {
+	if scenario.Version != ScenarioVersion {
+		return fmt.Errorf("unsupported version %d, the supported version is %d", scenario.Version, ScenarioVersion)
+	}
+	if _, err := IsCustomCredsProvided(scenario.GetBenchmarkConfig()); err != nil {
+		return errors.New("the server must have either all of url, username and password or none of them, the password can be set by " +
+			GetEnvVarName("password"))
+	}
+	if scenario.Server.Id != "" && scenario.Server.Url != "" {
+		return errors.New("the server must have either an id or a url, username and password")
+	}
+	if scenario.Server.Url != "" && !UrlStartsWithHttpMethod(scenario.Server.Url) {
+		return errors.New("the server url [" + scenario.Server.Url + "] must start with http:// or https://")
+	}
+	if err := ValidateRepoNameInput(scenario.Repository); err != nil {
+		return err
+	}
+	if len(scenario.Phases) == 0 {
+		return errors.New("at least one phase is required")
+	}
+	previousPhases := map[string]ScenarioPhase{}
+	for i, phase := range scenario.Phases {
+		if err := validateScenarioPhase(phase); err != nil {
+			return fmt.Errorf("phase %d (%s): %s", i+1, phase.Name, err.Error())
+		}
+		if _, exists := previousPhases[phase.Name]; exists {
+			return fmt.Errorf("phase %d: the name [%s] is used by more than one phase", i+1, phase.Name)
+		}
+		if err := validateScenarioPhaseOrder(scenario.Phases, i); err != nil {
+			return fmt.Errorf("phase %d (%s): %s", i+1, phase.Name, err.Error())
+		}
+		if err := validateScenarioPhaseSource(phase, previousPhases); err != nil {
+			return fmt.Errorf("phase %d (%s): %s", i+1, phase.Name, err.Error())
+		}
+		// A search phase doesn't generate files, unlike the search command, its queries are validated above.
+		if phase.Operation != "search" {
+			if err := newScenarioPhaseConfig(scenario.Repository, phase, previousPhases[phase.Source]).Validate(); err != nil {
+				return fmt.Errorf("phase %d (%s): %s", i+1, phase.Name, err.Error())
+			}
+		}
+		previousPhases[phase.Name] = phase
+	}
+	return nil
+}
+
+// Setup phases must come before all the other phases, and teardown phases after them.
+func validateScenarioPhaseOrder(phases []ScenarioPhase, index int) error {
+	if index == 0 {
+		return nil
+	}
+	previousRole := phases[index-1].Role
+	role := phases[index].Role
+	if role == scenarioRoleSetup && previousRole != scenarioRoleSetup {
+		return errors.New("setup phases must come before all the other phases")
+	}
+	if previousRole == scenarioRoleTeardown && role != scenarioRoleTeardown {
+		return errors.New("teardown phases must come after all the other phases")
+	}
+	return nil
+}
+
+func validateScenarioPhaseSource(phase ScenarioPhase, previousPhases map[string]ScenarioPhase) error {
+	if phase.Source == "" {
+		return nil
+	}
+	source, exists := previousPhases[phase.Source]
+	if !exists {
+		return errors.New("the source [" + phase.Source + "] must be the name of a previous phase")
+	}
+	if source.Operation != "upload" || source.Duration > 0 || source.Operations > 0 {
+		return errors.New("the source [" + phase.Source + "] must be an upload phase without duration and operations")
+	}
+	return nil
+}
+
+func validateScenarioPhase(phase ScenarioPhase) error {
+	if !scenarioPhaseNameRegexp.MatchString(phase.Name) {
+		return errors.New("the name must not be empty and can contain letters, numbers, dashes, dots and underscores only")
+	}
+	if !contains(scenarioOperations, phase.Operation) {
+		return errors.New("the operation must be one of " + strings.Join(scenarioOperations, ", "))
+	}
+	if !contains(scenarioRoles, phase.Role) {
+		return errors.New("the role must be one of " + strings.Join(scenarioRoles, ", "))
+	}
+	if phase.Concurrency < 0 || phase.Duration < 0 || phase.Operations < 0 || phase.Repeats < 0 {
+		return errors.New("concurrency, duration, operations and repeats must not be negative")
+	}
+	if phase.Duration > 0 && phase.Operations > 0 {
+		return errors.New("only one of duration and operations can be set")
+	}
+	if (phase.Duration > 0 || phase.Operations > 0) && phase.Operation != "upload" && phase.Operation != "download" {
+		return errors.New("duration and operations can be used with the upload and download operations only")
+	}
+	if phase.Operation == "search" {
+		if len(phase.Queries) == 0 && len(phase.Patterns) == 0 {
+			return errors.New("a search requires at least one of queries and patterns")
+		}
+		return nil
+	}
+	if phase.Source != "" {
+		if phase.Operation == "upload" {
+			return errors.New("source can be used with the download and delete operations only")
+		}
+		if phase.Size != 0 || phase.Files != 0 {
+			return errors.New("size and files are taken from the source and must not be set")
+		}
+		return nil
+	}
+	if phase.Size <= 0 || phase.Files <= 0 {
+		return errors.New("size and files must be positive")
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs the phases of the scenario one after the other, the files of every phase are uploaded to a folder named after it.
+// Once a phase fails the following phases are skipped, except for the teardown phases which always run.
+func MeasureScenarioOperationTimes(scenario *Scenario, servicesManager artifactory.ArtifactoryServicesManager,
+	scenarioResults *[]ScenarioResult) error {
+	var firstError error
+	phases := map[string]ScenarioPhase{}
+	files := new(scenarioFiles)
+	for _, phase := range scenario.Phases {
+		phases[phase.Name] = phase
+		if firstError != nil && phase.Role != scenarioRoleTeardown {
+			log.Info(fmt.Sprintf("Skipping phase [%s] of scenario [%s]", phase.Name, scenario.Name))
+			continue
+		}
+		log.Info(fmt.Sprintf("Running %s phase [%s] of scenario [%s]", phase.Role, phase.Name, scenario.Name))
+		var results []BenchmarkResult
+		err := runScenarioPhase(scenario.Repository, phase, phases[phase.Source], files, servicesManager, &results)
+		if err != nil {
+			err = errors.New("Phase [" + phase.Name + "] failed - " + err.Error())
+			if firstError == nil {
+				firstError = err
+			} else {
+				log.Error(err.Error())
+			}
+			continue
+		}
+		if phase.Role == scenarioRoleSetup || phase.Role == scenarioRoleTeardown {
+			continue
+		}
+		unit := "MB"
+		if phase.Operation == "search" {
+			unit = "items"
+		}
+		for _, result := range results {
+			*scenarioResults = append(*scenarioResults, ScenarioResult{BenchmarkResult: result, Phase: phase.Name, Role: phase.Role, Unit: unit})
+		}
+	}
+	return firstError
+}
+
+// Returns the config of the phase on the repository of the scenario, which is validated the same way as the config of
+// the command of its operation. A phase with a source uses the files of its source.
+func newScenarioPhaseConfig(repositoryName string, phase ScenarioPhase, source ScenarioPhase) *BenchmarkConfig {
+	phaseConfig := NewBenchmarkConfig(Operation(phase.Operation))
+	phaseConfig.RepositoryName = repositoryName
+	phaseConfig.FilesSizesInMb = phase.Size
+	phaseConfig.Iterations = phase.Files
+	if source.Name != "" {
+		phaseConfig.FilesSizesInMb = source.Size
+		phaseConfig.Iterations = source.Files
+	}
+	phaseConfig.AqlQueries = strings.Join(phase.Queries, "|")
+	phaseConfig.SearchPatterns = strings.Join(phase.Patterns, "|")
+	phaseConfig.SearchProps = ""
+	phaseConfig.Repeats = phase.Repeats
+	phaseConfig.Concurrency = phase.Concurrency
+	return phaseConfig
+}
+
+// The files generated for the phases of a scenario. All the phases generate the same File1.txt to FileN.txt files, so
+// they are generated again only for a phase which needs files of another size or more files.
+type scenarioFiles struct {
+	size  int
+	names []string
+}
+
+// Returns the names of the given number of files of the given size (in MB), generating them if needed.
+func (files *scenarioFiles) get(size int, count int) ([]string, error) {
+	if files.size != size || len(files.names) < count {
+		names, err := GenerateFiles(count, size, false)
+		if err != nil {
+			return nil, err
+		}
+		files.size = size
+		files.names = names
+	}
+	return files.names[:count], nil
+}
+
+// Runs a single phase. The source is the phase whose files are downloaded or deleted, its name is empty if the phase
+// uploads its own files.
+func runScenarioPhase(repositoryName string, phase ScenarioPhase, source ScenarioPhase, files *scenarioFiles,
+	servicesManager artifactory.ArtifactoryServicesManager, benchmarkResults *[]BenchmarkResult) error {
+	phaseConfig := newScenarioPhaseConfig(repositoryName, phase, source)
+	if phase.Operation == "search" {
+		// The searches are executed on the whole repository, including the files of all the previous phases.
+		return MeasureSearchOperationTimes(phaseConfig, servicesManager, benchmarkResults)
+	}
+	phaseConfig.RepositoryName = repositoryName + "/" + phase.Name
+	var fileNames []string
+	if source.Name != "" {
+		// Only the names of the files are needed, they were already uploaded by the source phase.
+		phaseConfig.RepositoryName = repositoryName + "/" + source.Name
+		for i := 1; i <= source.Files; i++ {
+			fileNames = append(fileNames, fmt.Sprintf("/tmp/testfiles/File%v.txt", i))
+		}
+	} else {
+		var err error
+		fileNames, err = files.get(phase.Size, phase.Files)
+		if err != nil {
+			return err
+		}
+		if phase.Operation != "upload" {
+			for _, file := range fileNames {
+				_, err := UploadFiles(file, phaseConfig.RepositoryName+"/", servicesManager)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	var operation runFunc
+	switch phase.Operation {
+	case "upload":
+		operation = func(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+			return UploadFilesWithoutChecksumDeploy(fileName, repositoryName+"/", servicesManager)
+		}
+	case "download":
+		operation = DownloadFiles
+	default:
+		operation = DeleteFiles
+	}
+	if phase.Duration > 0 || phase.Operations > 0 {
+		return runScenarioPhaseRepeatedly(phaseConfig, phase, fileNames, servicesManager, benchmarkResults, operation)
+	}
+	return runConcurrently(phase.Concurrency, len(fileNames), servicesManager, benchmarkResults, func(i int, servicesManager artifactory.ArtifactoryServicesManager) ([]BenchmarkResult, error) {
+		var result []BenchmarkResult
+		err := MeasureSingleOperation(fileNames[i], phaseConfig, servicesManager, &result, operation)
+		for j := range result {
+			result[j].Operation = phase.Operation
+		}
+		return result, err
+	})
+}
+
+// Repeats the operation of the phase over the files until its duration is over or its number of operations is reached.
+// Every worker uploads to its own sub-folder of the phase folder, so the same artifact is never uploaded concurrently.
+func runScenarioPhaseRepeatedly(phaseConfig *BenchmarkConfig, phase ScenarioPhase, fileNames []string,
+	servicesManager artifactory.ArtifactoryServicesManager, benchmarkResults *[]BenchmarkResult, operation runFunc) error {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstError error
+	deadline := time.Now().Add(time.Duration(phase.Duration) * time.Second)
+	isOver := func(i int) bool {
+		if phase.Operations > 0 {
+			return i >= phase.Operations
+		}
+		return !time.Now().Before(deadline)
+	}
+	workersServicesManagers, err := newWorkersServicesManagers(servicesManager, phase.Concurrency)
+	if err != nil {
+		return err
+	}
+	for worker := 0; worker < phase.Concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			workerConfig := *phaseConfig
+			if phase.Operation == "upload" {
+				workerConfig.RepositoryName += "/" + strconv.Itoa(worker)
+			}
+			for i := worker; !isOver(i); i += phase.Concurrency {
+				var result []BenchmarkResult
+				err := MeasureSingleOperation(fileNames[i%len(fileNames)], &workerConfig, workersServicesManagers[worker], &result, operation)
+				mutex.Lock()
+				if err != nil {
+					if firstError == nil {
+						firstError = err
+					}
+					mutex.Unlock()
+					return
+				}
+				for j := range result {
+					result[j].Operation = phase.Operation
+				}
+				*benchmarkResults = append(*benchmarkResults, result...)
+				mutex.Unlock()
+			}
+		}(worker)
+	}
+	wg.Wait()
+	return firstError
+}
+
+func WriteScenarioResults(filePath string, metadata *ResultsMetadata, scenarioResults []ScenarioResult) error {
+	file, newFile, err := openResultsFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	if err = writeMetadataHeader(writer, metadata); err != nil {
+		return err
+	}
+	if newFile {
+		fmt.Fprintln(writer, "phase,role,operation,path,size,unit,time taken (sec),speed (unit/sec)")
+	}
+	for _, result := range scenarioResults {
+		fmt.Fprintf(writer, "%s,%s,%s,%s,%s,%s,%s,%s\n", csvField(result.Phase), result.Role, result.Operation, csvField(result.FileName),
+			result.Size, result.Unit, result.Duration, result.Speed)
+	}
+	return nil
+}
+
+// Prints the stats of each operation type of every measure phase, in the order of the phases. The warm-up phases are
+// excluded, their results are only written to the results file.
+func PrintScenarioOperationStats(scenario *Scenario, scenarioResults []ScenarioResult) error {
+	for _, phase := range scenario.Phases {
+		if phase.Role != scenarioRoleMeasure {
+			continue
+		}
+		var results []BenchmarkResult
+		unit := "MB"
+		for _, result := range scenarioResults {
+			if result.Phase == phase.Name {
+				results = append(results, result.BenchmarkResult)
+				unit = result.Unit
+			}
+		}
+		fmt.Printf("phase: %s\n", phase.Name)
+		err := PrintOperationStats(results, unit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
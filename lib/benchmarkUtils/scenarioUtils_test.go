@@ -0,0 +1,293 @@
+package benchmarkUtils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testScenarioYaml = `version: 1
+name: nightly
+repository: benchmark-nightly
+output:
+  file: nightly.csv
+phases:
+  - name: upload-small
+    operation: upload
+    size: 1
+    files: 4
+    concurrency: 2
+  - name: search-all
+    operation: search
+    patterns: ["{repo}/*"]
+`
+
+const testScenarioJson = `{
+  "version": 1,
+  "server": {"url": "https://example.jfrog.io/artifactory", "username": "admin", "password": "password"},
+  "phases": [{"name": "download", "operation": "download", "size": 2, "files": 3, "duration": 60}]
+}`
+
+func writeTestScenario(t *testing.T, name string, content string) string {
+	localDir, err := ioutil.TempDir("", "benchmark")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(localDir) })
+	filePath := filepath.Join(localDir, name)
+	if err = ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write scenario file: %v", err)
+	}
+	return filePath
+}
+
+func TestLoadScenario(t *testing.T) {
+	scenario, err := LoadScenario(writeTestScenario(t, "bench.yaml", testScenarioYaml))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "nightly", scenario.Name)
+		assert.Equal(t, "benchmark-nightly", scenario.Repository)
+		assert.Equal(t, "nightly.csv", scenario.Output.File)
+		assert.Equal(t, []ScenarioPhase{
+			{Name: "upload-small", Operation: "upload", Role: "measure", Size: 1, Files: 4, Concurrency: 2, Repeats: 1},
+			{Name: "search-all", Operation: "search", Role: "measure", Patterns: []string{"{repo}/*"}, Concurrency: 1, Repeats: 1},
+		}, scenario.Phases)
+		assert.Equal(t, 4, scenario.GetBenchmarkConfig().Iterations)
+	}
+
+	scenario, err = LoadScenario(writeTestScenario(t, "bench.json", testScenarioJson))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "scenario", scenario.Name)
+		assert.Equal(t, "benchmark-scenario-tests", scenario.Repository)
+		assert.Equal(t, "admin", scenario.GetBenchmarkConfig().UserName)
+		assert.Equal(t, 60, scenario.Phases[0].Duration)
+	}
+
+	// The password can be left out of the file and set by the environment variable.
+	assert.NoError(t, os.Setenv("JFROG_BENCHMARK_PASSWORD", "secret"))
+	defer os.Unsetenv("JFROG_BENCHMARK_PASSWORD")
+	scenario, err = LoadScenario(writeTestScenario(t, "bench.yaml", testScenarioYaml+`server:
+  url: https://example.jfrog.io/artifactory
+  username: admin
+`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "secret", scenario.GetBenchmarkConfig().Password)
+	}
+
+	_, err = LoadScenario(writeTestScenario(t, "bench.toml", testScenarioYaml))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must have a .yaml, .yml or .json extension")
+	_, err = LoadScenario(writeTestScenario(t, "bench.yml", testScenarioYaml+"    concurency: 4\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field concurency not found")
+	_, err = LoadScenario(writeTestScenario(t, "bench.json", `{"version": 1, "phase": []}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "phase"`)
+}
+
+func TestValidateScenario(t *testing.T) {
+	validPhase := ScenarioPhase{Name: "upload", Operation: "upload", Role: "measure", Size: 1, Files: 1, Concurrency: 1, Repeats: 1}
+	newScenario := func(phases ...ScenarioPhase) *Scenario {
+		return &Scenario{Version: 1, Name: "scenario", Repository: "benchmark-scenario-tests", Phases: phases}
+	}
+	assert.NoError(t, ValidateScenario(newScenario(validPhase)))
+
+	scenario := newScenario(validPhase)
+	scenario.Version = 2
+	assert.EqualError(t, ValidateScenario(scenario), "unsupported version 2, the supported version is 1")
+	scenario = newScenario(validPhase)
+	scenario.Server.Url = "https://example.jfrog.io"
+	assert.EqualError(t, ValidateScenario(scenario),
+		"the server must have either all of url, username and password or none of them, the password can be set by JFROG_BENCHMARK_PASSWORD")
+	scenario.Server = ScenarioServer{Id: "benchmark", Url: "https://example.jfrog.io", Username: "admin", Password: "password"}
+	assert.EqualError(t, ValidateScenario(scenario), "the server must have either an id or a url, username and password")
+	scenario.Server = ScenarioServer{Id: "benchmark"}
+	assert.NoError(t, ValidateScenario(scenario))
+	assert.Equal(t, "benchmark", scenario.GetBenchmarkConfig().ServerId)
+	scenario = newScenario(validPhase)
+	scenario.Repository = "bad/repo"
+	assert.Error(t, ValidateScenario(scenario))
+	assert.EqualError(t, ValidateScenario(newScenario()), "at least one phase is required")
+	assert.EqualError(t, ValidateScenario(newScenario(validPhase, validPhase)), "phase 2: the name [upload] is used by more than one phase")
+
+	invalidPhases := map[string]ScenarioPhase{
+		"phase 1 (): the name must not be empty and can contain letters, numbers, dashes, dots and underscores only": {Operation: "upload", Role: "measure", Size: 1, Files: 1},
+		"phase 1 (copy): the operation must be one of upload, download, search, delete":                              {Name: "copy", Operation: "copy", Role: "measure", Size: 1, Files: 1},
+		"phase 1 (upload): the role must be one of setup, warmup, measure, teardown":                                 {Name: "upload", Operation: "upload", Role: "seed", Size: 1, Files: 1},
+		"phase 1 (upload): size and files must be positive":                                                          {Name: "upload", Operation: "upload", Role: "measure", Files: 1},
+		"phase 1 (upload): concurrency, duration, operations and repeats must not be negative":                       {Name: "upload", Operation: "upload", Role: "measure", Size: 1, Files: 1, Duration: -1},
+		"phase 1 (upload): only one of duration and operations can be set":                                           {Name: "upload", Operation: "upload", Role: "measure", Size: 1, Files: 1, Duration: 10, Operations: 10},
+		"phase 1 (search): a search requires at least one of queries and patterns":                                   {Name: "search", Operation: "search", Role: "measure"},
+		"phase 1 (delete): duration and operations can be used with the upload and download operations only":         {Name: "delete", Operation: "delete", Role: "measure", Size: 1, Files: 1, Duration: 10},
+		"phase 1 (search): duration and operations can be used with the upload and download operations only":         {Name: "search", Operation: "search", Role: "measure", Patterns: []string{"{repo}/*"}, Operations: 10},
+		"phase 1 (upload): source can be used with the download and delete operations only":                          {Name: "upload", Operation: "upload", Role: "measure", Source: "seed"},
+		"phase 1 (download): size and files are taken from the source and must not be set":                           {Name: "download", Operation: "download", Role: "measure", Source: "seed", Files: 1},
+		"phase 1 (download): the source [seed] must be the name of a previous phase":                                 {Name: "download", Operation: "download", Role: "measure", Source: "seed"},
+	}
+	for expectedError, phase := range invalidPhases {
+		assert.EqualError(t, ValidateScenario(newScenario(phase)), expectedError)
+	}
+
+	seed := ScenarioPhase{Name: "seed", Operation: "upload", Role: "setup", Size: 1, Files: 1, Concurrency: 1, Repeats: 1}
+	download := ScenarioPhase{Name: "download", Operation: "download", Role: "measure", Source: "seed", Concurrency: 1, Repeats: 1}
+	cleanup := ScenarioPhase{Name: "cleanup", Operation: "delete", Role: "teardown", Source: "seed", Concurrency: 1, Repeats: 1}
+	assert.NoError(t, ValidateScenario(newScenario(seed, download, cleanup)))
+	assert.EqualError(t, ValidateScenario(newScenario(download, seed)), "phase 1 (download): the source [seed] must be the name of a previous phase")
+	assert.EqualError(t, ValidateScenario(newScenario(validPhase, seed)), "phase 2 (seed): setup phases must come before all the other phases")
+	assert.EqualError(t, ValidateScenario(newScenario(seed, cleanup, download)), "phase 3 (download): teardown phases must come after all the other phases")
+	timedSeed := seed
+	timedSeed.Operations = 10
+	assert.EqualError(t, ValidateScenario(newScenario(timedSeed, download)),
+		"phase 2 (download): the source [seed] must be an upload phase without duration and operations")
+}
+
+func TestMeasureScenarioOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	defer os.RemoveAll("/tmp/testfiles")
+	defer os.RemoveAll(DownloadDirectory)
+	scenario := &Scenario{Version: 1, Name: "scenario", Repository: "benchmark-scenario-tests", Phases: []ScenarioPhase{
+		{Name: "up", Operation: "upload", Role: "measure", Size: 1, Files: 3, Concurrency: 2, Repeats: 1},
+		{Name: "dl", Operation: "download", Role: "measure", Size: 1, Files: 2, Concurrency: 1, Repeats: 1},
+		{Name: "search", Operation: "search", Role: "measure", Patterns: []string{"{repo}/up/*"}, Concurrency: 1, Repeats: 2},
+		{Name: "del", Operation: "delete", Role: "measure", Size: 1, Files: 2, Concurrency: 2, Repeats: 1},
+	}}
+
+	var results []ScenarioResult
+	assert.NoError(t, MeasureScenarioOperationTimes(scenario, servicesManager, &results))
+	if assert.Len(t, results, 9) {
+		for _, result := range results[:3] {
+			assert.Equal(t, "up", result.Phase)
+			assert.Equal(t, "upload", result.Operation)
+			assert.Equal(t, "MB", result.Unit)
+		}
+		assert.Equal(t, ScenarioResult{BenchmarkResult: results[3].BenchmarkResult, Phase: "dl", Role: "measure", Unit: "MB"}, results[3])
+		assert.Equal(t, "download", results[3].Operation)
+		assert.Equal(t, "search", results[5].Phase)
+		assert.Equal(t, "pattern", results[5].Operation)
+		assert.Equal(t, "3", results[5].Size)
+		assert.Equal(t, "items", results[5].Unit)
+		assert.Equal(t, "del", results[8].Phase)
+		assert.Equal(t, "delete", results[8].Operation)
+	}
+	assert.Equal(t, []string{"benchmark-scenario-tests/dl/File1.txt", "benchmark-scenario-tests/dl/File2.txt"}, fake.downloaded)
+	assert.ElementsMatch(t, []string{"benchmark-scenario-tests/del/File1.txt", "benchmark-scenario-tests/del/File2.txt"}, fake.deleted)
+	assert.Contains(t, fake.artifacts, "benchmark-scenario-tests/up/File3.txt")
+}
+
+func TestScenarioFiles(t *testing.T) {
+	defer os.RemoveAll("/tmp/testfiles")
+	files := new(scenarioFiles)
+	fileNames, err := files.get(1, 2)
+	if !assert.NoError(t, err) || !assert.Len(t, fileNames, 2) {
+		return
+	}
+	assert.NoError(t, ioutil.WriteFile(fileNames[0], []byte("marker"), os.ModePerm))
+
+	// The files are reused by a phase which needs fewer files of the same size.
+	fileNames, err = files.get(1, 1)
+	assert.NoError(t, err)
+	content, err := ioutil.ReadFile(fileNames[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "marker", string(content))
+
+	// And generated again for another size.
+	fileNames, err = files.get(2, 1)
+	assert.NoError(t, err)
+	info, err := os.Stat(fileNames[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2*1024*1024), info.Size())
+	}
+}
+
+func TestMeasureScenarioOperationTimesForDuration(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	defer os.RemoveAll("/tmp/testfiles")
+	scenario := &Scenario{Version: 1, Name: "scenario", Repository: "benchmark-scenario-tests", Phases: []ScenarioPhase{
+		{Name: "up", Operation: "upload", Role: "measure", Size: 1, Files: 2, Concurrency: 2, Duration: 1, Repeats: 1},
+	}}
+
+	var results []ScenarioResult
+	assert.NoError(t, MeasureScenarioOperationTimes(scenario, servicesManager, &results))
+	// Every worker keeps uploading the files to its own folder until the phase is over.
+	assert.Greater(t, len(results), 2)
+	assert.Contains(t, fake.artifacts, "benchmark-scenario-tests/up/0/File1.txt")
+	assert.Contains(t, fake.artifacts, "benchmark-scenario-tests/up/1/File2.txt")
+}
+
+func TestMeasureScenarioOperationTimesWithRoles(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	defer os.RemoveAll("/tmp/testfiles")
+	defer os.RemoveAll(DownloadDirectory)
+	scenario := &Scenario{Version: 1, Name: "scenario", Repository: "benchmark-scenario-tests", Phases: []ScenarioPhase{
+		{Name: "seed", Operation: "upload", Role: "setup", Size: 1, Files: 2, Concurrency: 2, Repeats: 1},
+		{Name: "warmup", Operation: "download", Role: "warmup", Source: "seed", Concurrency: 1, Operations: 3, Repeats: 1},
+		{Name: "dl", Operation: "download", Role: "measure", Source: "seed", Concurrency: 2, Operations: 4, Repeats: 1},
+		{Name: "cleanup", Operation: "delete", Role: "teardown", Source: "seed", Concurrency: 1, Repeats: 1},
+	}}
+
+	var results []ScenarioResult
+	assert.NoError(t, MeasureScenarioOperationTimes(scenario, servicesManager, &results))
+	// The setup and teardown phases are not measured.
+	if assert.Len(t, results, 7) {
+		for _, result := range results[:3] {
+			assert.Equal(t, "warmup", result.Phase)
+			assert.Equal(t, "warmup", result.Role)
+		}
+		for _, result := range results[3:] {
+			assert.Equal(t, "dl", result.Phase)
+			assert.Equal(t, "measure", result.Role)
+			assert.Equal(t, "1", result.Size)
+		}
+	}
+	assert.Len(t, fake.downloaded, 7)
+	for _, downloaded := range fake.downloaded {
+		assert.Contains(t, []string{"benchmark-scenario-tests/seed/File1.txt", "benchmark-scenario-tests/seed/File2.txt"}, downloaded)
+	}
+	assert.ElementsMatch(t, []string{"benchmark-scenario-tests/seed/File1.txt", "benchmark-scenario-tests/seed/File2.txt"}, fake.deleted)
+}
+
+func TestMeasureScenarioOperationTimesRunsTeardownAfterFailure(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	defer os.RemoveAll("/tmp/testfiles")
+	defer os.RemoveAll(DownloadDirectory)
+	scenario := &Scenario{Version: 1, Name: "scenario", Repository: "benchmark-scenario-tests", Phases: []ScenarioPhase{
+		{Name: "seed", Operation: "upload", Role: "setup", Size: 1, Files: 1, Concurrency: 1, Repeats: 1},
+		{Name: "other-seed", Operation: "upload", Role: "setup", Size: 1, Files: 1, Concurrency: 1, Repeats: 1},
+		{Name: "del", Operation: "delete", Role: "measure", Source: "seed", Concurrency: 1, Repeats: 1},
+		// The files of the seed phase were deleted by the previous phase.
+		{Name: "dl", Operation: "download", Role: "measure", Source: "seed", Concurrency: 1, Repeats: 1},
+		{Name: "other-dl", Operation: "download", Role: "measure", Source: "other-seed", Concurrency: 1, Repeats: 1},
+		{Name: "cleanup", Operation: "delete", Role: "teardown", Source: "other-seed", Concurrency: 1, Repeats: 1},
+	}}
+
+	var results []ScenarioResult
+	err := MeasureScenarioOperationTimes(scenario, servicesManager, &results)
+	assert.EqualError(t, err, "Phase [dl] failed - Failed to download files from Artifactory")
+	assert.Len(t, results, 1)
+	assert.Empty(t, fake.downloaded)
+	assert.Equal(t, []string{"benchmark-scenario-tests/seed/File1.txt", "benchmark-scenario-tests/other-seed/File1.txt"}, fake.deleted)
+}
+
+func TestWriteScenarioResults(t *testing.T) {
+	filePath := "scenario-results.csv"
+	defer os.Remove(filePath)
+	results := []ScenarioResult{
+		{BenchmarkResult: *NewMBBenchmarkResult("upload", "/tmp/testfiles/File1.txt", 2, 2*time.Second), Phase: "up", Role: "measure", Unit: "MB"},
+		{BenchmarkResult: *NewItemsBenchmarkResult("aql", `items.find({"repo":"a"})`, 10, time.Second), Phase: "search", Role: "warmup", Unit: "items"},
+	}
+	assert.NoError(t, WriteScenarioResults(filePath, nil, results))
+	assert.NoError(t, WriteScenarioResults(filePath, nil, results[:1]))
+	data, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "phase,role,operation,path,size,unit,time taken (sec),speed (unit/sec)\n"+
+		"up,measure,upload,/tmp/testfiles/File1.txt,2,MB,2s,1.00\n"+
+		`search,warmup,aql,"items.find({""repo"":""a""})",10,items,1s,10.00`+"\n"+
+		"up,measure,upload,/tmp/testfiles/File1.txt,2,MB,2s,1.00\n", string(data))
+}
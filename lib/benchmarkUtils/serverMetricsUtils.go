@@ -0,0 +1,239 @@
+package benchmarkUtils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	openMetricsSource = "open metrics"
+	storageSource     = "storage info"
+)
+
+// The server side indicators collected during a run, the deltas are between the first sample, taken before the measured
+// operations, and the last one, taken after them. The samples are written to the SamplesFile next to the results file.
+type ServerMetrics struct {
+	Samples     []ServerMetricsSample `json:"-"`
+	SamplesFile string                `json:"samplesFile"`
+	Deltas      map[string]float64    `json:"deltas"`
+}
+
+type ServerMetricsSample struct {
+	Time   time.Time
+	Values map[string]float64
+}
+
+// Returns the server metrics of the samples, with the delta of every metric that is in both the first and last samples.
+func NewServerMetrics(samples []ServerMetricsSample) *ServerMetrics {
+	serverMetrics := &ServerMetrics{Samples: samples, Deltas: map[string]float64{}}
+	if len(samples) == 0 {
+		return serverMetrics
+	}
+	first, last := samples[0].Values, samples[len(samples)-1].Values
+	for metric, lastValue := range last {
+		if firstValue, ok := first[metric]; ok {
+			serverMetrics.Deltas[metric] = lastValue - firstValue
+		}
+	}
+	return serverMetrics
+}
+
+// Samples the Open Metrics endpoint and the storage info of Artifactory once when started, every interval while running,
+// and once when stopped. A source that fails, such as the metrics endpoint when the metrics aren't enabled on the
+// server, is logged once and the samples contain the metrics of the other source. The poller has its own services
+// manager, as it sends its requests while the measured operations are running.
+type serverMetricsPoller struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	repositoryName  string
+	samples         []ServerMetricsSample
+	failedSources   map[string]bool
+	stopped         chan struct{}
+	done            chan struct{}
+}
+
+// Returns nil if the services manager of the poller can't be created, the run is then executed without the server metrics.
+func startServerMetricsPoller(st *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager) *serverMetricsPoller {
+	config := servicesManager.GetConfig()
+	pollerServicesManager, err := newServicesManagerWithProgress(config, config.GetThreads(), nil)
+	if err != nil {
+		log.Warn("Failed to collect the server metrics, they are excluded from the run - " + err.Error())
+		return nil
+	}
+	poller := &serverMetricsPoller{servicesManager: pollerServicesManager, repositoryName: st.RepositoryName,
+		failedSources: map[string]bool{}, stopped: make(chan struct{}), done: make(chan struct{})}
+	log.Info(fmt.Sprintf("Collecting the server metrics every %s", st.ServerMetricsInterval))
+	poller.sample()
+	go poller.run(st.ServerMetricsInterval)
+	return poller
+}
+
+func (poller *serverMetricsPoller) run(interval time.Duration) {
+	defer close(poller.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			poller.sample()
+		case <-poller.stopped:
+			return
+		}
+	}
+}
+
+func (poller *serverMetricsPoller) stop() []ServerMetricsSample {
+	close(poller.stopped)
+	<-poller.done
+	poller.sample()
+	return poller.samples
+}
+
+func (poller *serverMetricsPoller) sample() {
+	values := map[string]float64{}
+	poller.addValues(openMetricsSource, values, poller.getOpenMetrics)
+	poller.addValues(storageSource, values, poller.getStorageMetrics)
+	if len(values) > 0 {
+		poller.samples = append(poller.samples, ServerMetricsSample{Time: time.Now(), Values: values})
+	}
+}
+
+func (poller *serverMetricsPoller) addValues(source string, values map[string]float64, getValues func() (map[string]float64, error)) {
+	if poller.failedSources[source] {
+		return
+	}
+	sourceValues, err := getValues()
+	if err != nil {
+		log.Warn("Failed to collect the " + source + " server metrics, they are excluded from the run - " + err.Error())
+		poller.failedSources[source] = true
+		return
+	}
+	for metric, value := range sourceValues {
+		values[metric] = value
+	}
+}
+
+func (poller *serverMetricsPoller) getOpenMetrics() (map[string]float64, error) {
+	serviceDetails := poller.servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := poller.servicesManager.Client().SendGet(serviceDetails.GetUrl()+"api/v1/metrics", true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("Artifactory response: " + resp.Status + "\n" + string(body))
+	}
+	return ParseOpenMetrics(string(body)), nil
+}
+
+// Returns the number of binaries, items and artifacts on the server, and the number of files, items and used bytes of the
+// repository of the run. The storage info is cached by Artifactory, so it is recalculated before it is read.
+func (poller *serverMetricsPoller) getStorageMetrics() (map[string]float64, error) {
+	storageInfo, err := poller.servicesManager.StorageInfo(true)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]float64{}
+	addStorageMetric(values, "storage_binaries_count", storageInfo.BinariesCount)
+	addStorageMetric(values, "storage_items_count", storageInfo.ItemsCount)
+	addStorageMetric(values, "storage_artifacts_count", storageInfo.ArtifactsCount)
+	for _, repository := range storageInfo.RepositoriesSummaryList {
+		if repository.RepoKey != poller.repositoryName {
+			continue
+		}
+		labels := `{repo="` + repository.RepoKey + `"}`
+		addStorageMetric(values, "storage_repo_files_count"+labels, repository.FilesCount.String())
+		addStorageMetric(values, "storage_repo_items_count"+labels, repository.ItemsCount.String())
+		addStorageMetric(values, "storage_repo_used_space_bytes"+labels, repository.UsedSpaceInBytes.String())
+	}
+	return values, nil
+}
+
+// The counts of the storage info are formatted with thousands separators, such as 1,234.
+func addStorageMetric(values map[string]float64, metric string, value string) {
+	parsed, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err == nil {
+		values[metric] = parsed
+	}
+}
+
+// Parses the samples of the Open Metrics text format into the values of their series, such as
+// jfrt_db_connections_active_total{pool="default"}. Values that aren't finite numbers are skipped.
+func ParseOpenMetrics(data string) map[string]float64 {
+	values := map[string]float64{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// The series is followed by its value and an optional timestamp, the labels of the series may contain spaces.
+		var series string
+		var fields []string
+		if labelsEnd := strings.LastIndex(line, "}"); labelsEnd >= 0 {
+			series = line[:labelsEnd+1]
+			fields = strings.Fields(line[labelsEnd+1:])
+		} else {
+			fields = strings.Fields(line)
+			series = fields[0]
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		values[series] = value
+	}
+	return values
+}
+
+// Returns the path of the server metrics file of the results file, results.csv has the results-server-metrics.csv file.
+func GetServerMetricsFilePath(resultsFilePath string) string {
+	return strings.TrimSuffix(resultsFilePath, filepath.Ext(resultsFilePath)) + "-server-metrics.csv"
+}
+
+// Writes every metric of every sample as a row, with the seconds elapsed since the first sample.
+func WriteServerMetricsSamples(filePath string, samples []ServerMetricsSample) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return errors.New("Failed to write the server metrics file [" + filePath + "] - " + err.Error())
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+	fmt.Fprintln(writer, "time,elapsed (sec),metric,value")
+	for _, sample := range samples {
+		var metrics []string
+		for metric := range sample.Values {
+			metrics = append(metrics, metric)
+		}
+		sort.Strings(metrics)
+		elapsed := sample.Time.Sub(samples[0].Time).Seconds()
+		for _, metric := range metrics {
+			fmt.Fprintf(writer, "%s,%.3f,%s,%s\n", sample.Time.Format(time.RFC3339Nano), elapsed, csvField(metric),
+				strconv.FormatFloat(sample.Values[metric], 'f', -1, 64))
+		}
+	}
+	return nil
+}
+
+func ValidateServerMetricsInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.ServerMetricsInterval < 0 {
+		return errors.New("Server metrics interval must be a positive number of seconds")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package benchmarkUtils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOpenMetrics(t *testing.T) {
+	data := `# HELP jfrt_db_connections_active_total Total Active Connections
+# TYPE jfrt_db_connections_active_total gauge
+jfrt_db_connections_active_total 3 1677000000000
+jfrt_http_connections_max_total{max="50", pool="default"} 50
+jfrt_runtime_heap_freememory_bytes NaN
+
+jfrt_artifacts_gc_duration_seconds +Inf
+`
+	assert.Equal(t, map[string]float64{
+		"jfrt_db_connections_active_total":                          3,
+		`jfrt_http_connections_max_total{max="50", pool="default"}`: 50,
+	}, ParseOpenMetrics(data))
+	assert.Empty(t, ParseOpenMetrics(""))
+}
+
+func TestNewServerMetrics(t *testing.T) {
+	start := time.Now()
+	samples := []ServerMetricsSample{
+		{Time: start, Values: map[string]float64{"queue": 2, "pool": 10, "first": 1}},
+		{Time: start.Add(time.Second), Values: map[string]float64{"queue": 8, "pool": 10}},
+		{Time: start.Add(2 * time.Second), Values: map[string]float64{"queue": 5, "pool": 4, "last": 1}},
+	}
+	assert.Equal(t, map[string]float64{"queue": 3, "pool": -6}, NewServerMetrics(samples).Deltas)
+	assert.Empty(t, NewServerMetrics(nil).Deltas)
+}
+
+func TestWriteServerMetricsSamples(t *testing.T) {
+	filePath := "server-metrics.csv"
+	defer os.Remove(filePath)
+	start := time.Date(2023, 2, 21, 11, 30, 0, 0, time.UTC)
+	samples := []ServerMetricsSample{
+		{Time: start, Values: map[string]float64{"queue": 2, `pool{a="1",b="2"}`: 10}},
+		{Time: start.Add(1500 * time.Millisecond), Values: map[string]float64{"queue": 8.5}},
+	}
+	assert.NoError(t, WriteServerMetricsSamples(filePath, samples))
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "time,elapsed (sec),metric,value\n"+
+		`2023-02-21T11:30:00Z,0.000,"pool{a=""1"",b=""2""}",10`+"\n"+
+		"2023-02-21T11:30:00Z,0.000,queue,2\n"+
+		"2023-02-21T11:30:01.5Z,1.500,queue,8.5\n", string(content))
+}
+
+func TestCollectServerMetrics(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{"benchmark-up-tests/Existing.txt": []byte("1")})
+	defer fake.Close()
+	fake.metrics = "jfrt_db_connections_active_total 3\n"
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+	resultsFilePath := "server-metrics-results.csv"
+	defer os.Remove(GetMetadataFilePath(resultsFilePath))
+	defer os.Remove(GetServerMetricsFilePath(resultsFilePath))
+	defer os.Remove(GetClientResourcesFilePath(resultsFilePath))
+
+	config := &BenchmarkConfig{Operation: UploadOperation, RepositoryName: "benchmark-up-tests", ServerMetricsInterval: 10 * time.Millisecond}
+	metadata := NewResultsMetadata(config, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	// The poller doesn't share the services manager of the measured operations.
+	if assert.NotNil(t, metadata.serverMetricsPoller) {
+		assert.NotSame(t, servicesManager, metadata.serverMetricsPoller.servicesManager)
+	}
+	for _, fileName := range fileNames {
+		_, err := UploadFiles(fileName, config.RepositoryName, servicesManager)
+		assert.NoError(t, err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	assert.NoError(t, WriteResultsMetadata(resultsFilePath, metadata))
+
+	if assert.NotNil(t, metadata.ServerMetrics) {
+		assert.GreaterOrEqual(t, len(metadata.ServerMetrics.Samples), 3)
+		assert.Equal(t, map[string]float64{
+			"jfrt_db_connections_active_total":                         0,
+			"storage_binaries_count":                                   2,
+			"storage_items_count":                                      2,
+			"storage_artifacts_count":                                  2,
+			`storage_repo_files_count{repo="benchmark-up-tests"}`:      2,
+			`storage_repo_items_count{repo="benchmark-up-tests"}`:      2,
+			`storage_repo_used_space_bytes{repo="benchmark-up-tests"}`: 20,
+		}, metadata.ServerMetrics.Deltas)
+		assert.Equal(t, 1001.0, metadata.ServerMetrics.Samples[0].Values["storage_binaries_count"])
+		// The storage info is recalculated before every sample.
+		assert.Equal(t, len(metadata.ServerMetrics.Samples), fake.storageRefreshes)
+	}
+	assert.FileExists(t, GetServerMetricsFilePath(resultsFilePath))
+	data, err := ioutil.ReadFile(GetMetadataFilePath(resultsFilePath))
+	assert.NoError(t, err)
+	var written struct {
+		ServerMetrics ServerMetrics `json:"serverMetrics"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, "server-metrics-results-server-metrics.csv", written.ServerMetrics.SamplesFile)
+	assert.Equal(t, 2.0, written.ServerMetrics.Deltas["storage_binaries_count"])
+}
+
+func TestCollectServerMetricsWithoutOpenMetrics(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	config := &BenchmarkConfig{Operation: DownloadOperation, RepositoryName: "benchmark-dl-tests", ServerMetricsInterval: time.Hour}
+	metadata := NewResultsMetadata(config, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	metadata.end()
+
+	// The metrics endpoint isn't enabled, the storage info is still collected.
+	if assert.NotNil(t, metadata.ServerMetrics) && assert.Len(t, metadata.ServerMetrics.Samples, 2) {
+		assert.Equal(t, map[string]float64{"storage_binaries_count": 1000, "storage_items_count": 0, "storage_artifacts_count": 0},
+			metadata.ServerMetrics.Samples[1].Values)
+	}
+
+	metadata = NewResultsMetadata(&BenchmarkConfig{Operation: DownloadOperation}, servicesManager)
+	metadata.StartMonitors(servicesManager)
+	metadata.end()
+	assert.Nil(t, metadata.ServerMetrics)
+}
+
+func TestValidateServerMetricsInput(t *testing.T) {
+	assert.NoError(t, ValidateServerMetricsInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidateServerMetricsInput(&BenchmarkConfig{ServerMetricsInterval: 5 * time.Second}))
+	assert.EqualError(t, ValidateServerMetricsInput(&BenchmarkConfig{ServerMetricsInterval: -time.Second}),
+		"Server metrics interval must be a positive number of seconds")
+}
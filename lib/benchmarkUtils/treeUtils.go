@@ -0,0 +1,177 @@
+package benchmarkUtils
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientConfig "github.com/jfrog/jfrog-client-go/config"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The folder inside the repository the generated tree is uploaded to.
+const treeUploadFolder = "tree"
+
+// Generates a directory hierarchy under the root directory, where every directory up to the given depth has fanOut
+// sub-directories, and spreads the given number of files of sizeInKb KB over all the directories of the hierarchy.
+func GenerateFileTree(rootDirectory string, depth int, fanOut int, numberOfFiles int, sizeInKb int) ([]string, error) {
+	log.Info(fmt.Sprintf("Generating %d files of %dKB in a directory tree of depth %d and fan-out %d", numberOfFiles, sizeInKb, depth, fanOut))
+	directories := []string{rootDirectory}
+	level := []string{rootDirectory}
+	for d := 1; d <= depth; d++ {
+		var nextLevel []string
+		for _, parent := range level {
+			for i := 1; i <= fanOut; i++ {
+				nextLevel = append(nextLevel, filepath.Join(parent, fmt.Sprintf("dir%d", i)))
+			}
+		}
+		directories = append(directories, nextLevel...)
+		level = nextLevel
+	}
+	for _, directory := range directories {
+		err := os.MkdirAll(directory, os.ModePerm)
+		if err != nil {
+			return nil, errors.New("Failed to create directory [" + directory + "] - " + err.Error())
+		}
+	}
+	var fileNames []string
+	data := make([]byte, sizeInKb*1024)
+	for i := 1; i <= numberOfFiles; i++ {
+		fileName := filepath.Join(directories[(i-1)%len(directories)], fmt.Sprintf("File%v.txt", i))
+		rand.Read(data)
+		err := ioutil.WriteFile(fileName, data, os.ModePerm)
+		if err != nil {
+			return nil, errors.New("Failed to create file [" + fileName + "] - " + err.Error())
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	return fileNames, nil
+}
+
+// Uploads the whole directory tree to the given folder of the repository with a single recursive pattern, keeping the
+// hierarchy of the tree. The upload uses the given number of threads, and the time of transferring each of the files is
+// returned in addition to the total time, keyed by the path of the file in the repository.
+func UploadFileTree(rootDirectory string, repositoryName string, folderName string, threads int,
+	servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, map[string]time.Duration, error) {
+	progress := newFileTimesProgress(servicesManager.GetConfig().GetServiceDetails().GetUrl())
+	treeServicesManager, err := newServicesManagerWithProgress(servicesManager.GetConfig(), threads, progress)
+	if err != nil {
+		return 0, nil, err
+	}
+	up := services.NewUploadParams()
+	up.CommonParams = &utils.CommonParams{Pattern: filepath.Join(rootDirectory, "(*)"), Recursive: true,
+		Target: repositoryName + "/" + folderName + "/{1}"}
+	start := time.Now()
+	totalSucceeded, totalFailed, err := treeServicesManager.UploadFiles(up)
+	end := time.Since(start)
+	if err != nil {
+		return 0, nil, err
+	}
+	if totalFailed > 0 || totalSucceeded == 0 {
+		return 0, nil, fmt.Errorf("Failed to upload %d of the %d files of [%s]", totalFailed, totalSucceeded+totalFailed, rootDirectory)
+	}
+	return end, progress.fileTimes, nil
+}
+
+// Creates a services manager with the same server details of the given config, which uses the given number of threads
+// and reports the transfers of the files to the given progress manager.
+func newServicesManagerWithProgress(config clientConfig.Config, threads int, progress ioutils.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
+	serviceConfig, err := clientConfig.NewConfigBuilder().
+		SetServiceDetails(config.GetServiceDetails()).
+		SetCertificatesPath(config.GetCertificatesPath()).
+		SetInsecureTls(config.IsInsecureTls()).
+		SetHttpRetries(config.GetHttpRetries()).
+		SetHttpRetryWaitMilliSecs(config.GetHttpRetryWaitMilliSecs()).
+		SetThreads(threads).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return artifactory.NewWithProgress(serviceConfig, progress)
+}
+
+// Uploads the generated directory tree with a single recursive upload ('upload-tree' operation), and breaks it down to
+// the time of transferring each of its files ('upload-tree-file' operation).
+func MeasureTreeOperationTimes(st *BenchmarkConfig, rootDirectory string, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	duration, fileTimes, err := UploadFileTree(rootDirectory, st.RepositoryName, treeUploadFolder, st.Threads, servicesManager)
+	if err != nil {
+		return err
+	}
+	*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("upload-tree", st.RepositoryName+"/"+treeUploadFolder+"/", len(fileNames), duration))
+	for _, file := range fileNames {
+		relativePath, _ := filepath.Rel(rootDirectory, file)
+		artifactPath := st.RepositoryName + "/" + treeUploadFolder + "/" + filepath.ToSlash(relativePath)
+		fileDuration, ok := fileTimes[artifactPath]
+		if !ok {
+			return errors.New("The transfer time of [" + artifactPath + "] wasn't reported by the upload")
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("upload-tree-file", artifactPath, 1, fileDuration))
+	}
+	return nil
+}
+
+// A progress manager which doesn't display anything, and only records the time from the start of each file transfer to
+// its end.
+type fileTimesProgress struct {
+	mutex     sync.Mutex
+	serverUrl string
+	lastId    int
+	starts    map[int]fileTransferStart
+	fileTimes map[string]time.Duration
+}
+
+type fileTransferStart struct {
+	artifactPath string
+	start        time.Time
+}
+
+func newFileTimesProgress(serverUrl string) *fileTimesProgress {
+	return &fileTimesProgress{serverUrl: serverUrl, starts: map[int]fileTransferStart{}, fileTimes: map[string]time.Duration{}}
+}
+
+// The path is the URL of the uploaded artifact, which may contain matrix parameters.
+func (p *fileTimesProgress) NewProgressReader(total int64, label, path string) ioutils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.lastId++
+	artifactPath := strings.SplitN(strings.TrimPrefix(path, p.serverUrl), ";", 2)[0]
+	p.starts[p.lastId] = fileTransferStart{artifactPath: artifactPath, start: time.Now()}
+	return &fileTimeProgress{id: p.lastId}
+}
+
+func (p *fileTimesProgress) RemoveProgress(id int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if transfer, ok := p.starts[id]; ok {
+		p.fileTimes[transfer.artifactPath] = time.Since(transfer.start)
+		delete(p.starts, id)
+	}
+}
+
+func (p *fileTimesProgress) SetProgressState(id int, state string) {}
+func (p *fileTimesProgress) GetProgress(id int) ioutils.Progress   { return &fileTimeProgress{id: id} }
+func (p *fileTimesProgress) Quit() error                           { return nil }
+func (p *fileTimesProgress) IncGeneralProgressTotalBy(n int64)     {}
+func (p *fileTimesProgress) SetHeadlineMsg(msg string)             {}
+func (p *fileTimesProgress) ClearHeadlineMsg()                     {}
+func (p *fileTimesProgress) InitProgressReaders()                  {}
+
+type fileTimeProgress struct {
+	id int
+}
+
+func (p *fileTimeProgress) ActionWithProgress(reader io.Reader) io.Reader { return reader }
+func (p *fileTimeProgress) Abort()                                        {}
+func (p *fileTimeProgress) GetId() int                                    { return p.id }
@@ -0,0 +1,63 @@
+package benchmarkUtils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateFileTree(t *testing.T) {
+	localDir, err := ioutil.TempDir("", "benchmark")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	// A tree of depth 2 and fan-out 2 has 1 + 2 + 4 directories.
+	fileNames, err := GenerateFileTree(filepath.Join(localDir, "tree"), 2, 2, 10, 1)
+	assert.NoError(t, err)
+	assert.Len(t, fileNames, 10)
+	assert.Equal(t, filepath.Join(localDir, "tree", "File1.txt"), fileNames[0])
+	assert.Equal(t, filepath.Join(localDir, "tree", "dir1", "File2.txt"), fileNames[1])
+	assert.Equal(t, filepath.Join(localDir, "tree", "dir2", "dir2", "File7.txt"), fileNames[6])
+	assert.Equal(t, filepath.Join(localDir, "tree", "File8.txt"), fileNames[7])
+	info, err := os.Stat(fileNames[9])
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1024), info.Size())
+	}
+}
+
+func TestMeasureTreeOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, err := ioutil.TempDir("", "benchmark")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(localDir)
+	rootDirectory := filepath.Join(localDir, "tree")
+	fileNames, err := GenerateFileTree(rootDirectory, 1, 2, 5, 1)
+	assert.NoError(t, err)
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-tree-tests", Threads: 2}
+	assert.NoError(t, MeasureTreeOperationTimes(config, rootDirectory, fileNames, servicesManager, &results))
+	if assert.Len(t, results, 6) {
+		assert.Equal(t, "upload-tree", results[0].Operation)
+		assert.Equal(t, "benchmark-tree-tests/tree/", results[0].FileName)
+		assert.Equal(t, "5", results[0].Size)
+		assert.Equal(t, "upload-tree-file", results[2].Operation)
+		assert.Equal(t, "benchmark-tree-tests/tree/dir1/File2.txt", results[2].FileName)
+		assert.Equal(t, "1", results[2].Size)
+	}
+	assert.ElementsMatch(t, []string{
+		"benchmark-tree-tests/tree/File1.txt",
+		"benchmark-tree-tests/tree/dir1/File2.txt",
+		"benchmark-tree-tests/tree/dir2/File3.txt",
+		"benchmark-tree-tests/tree/File4.txt",
+		"benchmark-tree-tests/tree/dir1/File5.txt",
+	}, fake.uploaded)
+}
@@ -5,29 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
+	mathRand "math/rand"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jfrog/jfrog-client-go/artifactory"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-type BenchmarkConfig struct {
-	FilesSizesInMb string
-	Iterations     string
-	RepositoryName string
-	Operation      string
-	Url            string
-	UserName       string
-	Password       string
-	Append         string
-	SameFile       bool
-}
-
 func GenerateFiles(numberOfFiles int, sizeOfFilesInMB int, sameFile bool) ([]string, error) {
 	log.Info("Starting to generate files locally")
 	sliceOfFileNames := []string{}
@@ -66,14 +58,14 @@ func MeasureOperationTimes(st *BenchmarkConfig, fileNames []string, servicesMana
 		// 	}
 		// 	file = firstFile
 		// }
-		if st.Operation == "upload" {
-			uploadError := MeasureSingleOperation(file, st, servicesManager, *&benchmarkResults, UploadFiles)
+		if st.Operation == UploadOperation {
+			uploadError := MeasureSingleOperation(file, st, servicesManager, *&benchmarkResults, UploadFilesWithoutChecksumDeploy)
 			if uploadError != nil {
 				return uploadError
 			}
 		}
-		if st.Operation == "download" {
-			downloadError := MeasureSingleOperation(file, st, servicesManager, *&benchmarkResults, DownloadFiles)
+		if st.Operation == DownloadOperation {
+			downloadError := MeasureSingleOperation(file, st, servicesManager, *&benchmarkResults, GetDownloadFunc(st))
 			if downloadError != nil {
 				return downloadError
 			}
@@ -82,18 +74,288 @@ func MeasureOperationTimes(st *BenchmarkConfig, fileNames []string, servicesMana
 	return nil
 }
 
+// Returns the download operation with the split settings of the config.
+func GetDownloadFunc(st *BenchmarkConfig) runFunc {
+	return func(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+		return DownloadFilesWithSplit(fileName, repositoryName, st.SplitCount, st.MinSplitSize, servicesManager)
+	}
+}
+
+// Runs the given number of operations using concurrent workers, where ReadRatio percent of them are downloads of the
+// provided files and the rest are uploads of the same files to a dedicated folder in the repository. Every upload is
+// written to its own sub-folder of that folder, so the same artifact is never uploaded concurrently, even with same_file.
+func MeasureMixedOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	operations := GetMixedOperations(st.Iterations, st.ReadRatio)
+
+	return runConcurrently(st.Concurrency, len(operations), servicesManager, benchmarkResults, func(i int, servicesManager artifactory.ArtifactoryServicesManager) ([]BenchmarkResult, error) {
+		var result []BenchmarkResult
+		operation := GetDownloadFunc(st)
+		if operations[i] == "upload" {
+			operation = func(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error) {
+				return UploadFilesWithoutChecksumDeploy(fileName, repositoryName+"/"+mixedWritesFolder+"/"+strconv.Itoa(i)+"/", servicesManager)
+			}
+		}
+		err := MeasureSingleOperation(fileNames[i%len(fileNames)], st, servicesManager, &result, operation)
+		for j := range result {
+			result[j].Operation = operations[i]
+		}
+		return result, err
+	})
+}
+
+// Runs the tasks with the indexes 0 to numberOfTasks-1 using the given number of concurrent workers, and appends the
+// results of each task to benchmarkResults. Every worker passes its own services manager to the tasks it runs. All the
+// tasks are executed even if some fail, and the first error is returned.
+func runConcurrently(concurrency int, numberOfTasks int, servicesManager artifactory.ArtifactoryServicesManager, benchmarkResults *[]BenchmarkResult,
+	task func(i int, servicesManager artifactory.ArtifactoryServicesManager) ([]BenchmarkResult, error)) error {
+	workersServicesManagers, err := newWorkersServicesManagers(servicesManager, concurrency)
+	if err != nil {
+		return err
+	}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstError error
+	tasksIndexes := make(chan int)
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func(workerServicesManager artifactory.ArtifactoryServicesManager) {
+			defer wg.Done()
+			for i := range tasksIndexes {
+				results, err := task(i, workerServicesManager)
+				mutex.Lock()
+				if err != nil && firstError == nil {
+					firstError = err
+				}
+				*benchmarkResults = append(*benchmarkResults, results...)
+				mutex.Unlock()
+			}
+		}(workersServicesManagers[worker])
+	}
+	for i := 0; i < numberOfTasks; i++ {
+		tasksIndexes <- i
+	}
+	close(tasksIndexes)
+	wg.Wait()
+	return firstError
+}
+
+// Returns a services manager with the same config as the given one for each of the concurrent workers, as the http client
+// of a services manager doesn't support concurrent requests. The workers of a throttled services manager share its
+// bandwidth limit.
+func newWorkersServicesManagers(servicesManager artifactory.ArtifactoryServicesManager, workers int) ([]artifactory.ArtifactoryServicesManager, error) {
+	config := servicesManager.GetConfig()
+	// The server details are shared by the services managers, and cache the Artifactory version the first time it is
+	// needed, so it is cached before the workers start.
+	if _, err := config.GetServiceDetails().GetVersion(); err != nil {
+		return nil, err
+	}
+	throttledManager, throttled := servicesManager.(*throttledServicesManager)
+	var progress ioutils.ProgressMgr
+	if throttled {
+		progress = throttledManager.progress
+	}
+	workersServicesManagers := make([]artifactory.ArtifactoryServicesManager, workers)
+	for worker := range workersServicesManagers {
+		workerServicesManager, err := newServicesManagerWithProgress(config, config.GetThreads(), progress)
+		if err != nil {
+			return nil, err
+		}
+		if throttled {
+			workerServicesManager = &throttledServicesManager{ArtifactoryServicesManager: workerServicesManager, progress: progress}
+		}
+		workersServicesManagers[worker] = workerServicesManager
+	}
+	return workersServicesManagers, nil
+}
+
+// The folder inside the repository the uploads of a mixed workload are written to, so they don't override the downloaded files.
+const mixedWritesFolder = "writes"
+
+// Returns the operations of a mixed workload in random order, readRatio percent of them are downloads and the rest are uploads.
+func GetMixedOperations(numberOfOperations int, readRatio int) []string {
+	reads := int(math.Round(float64(numberOfOperations*readRatio) / 100))
+	operations := make([]string, numberOfOperations)
+	for i := range operations {
+		if i < reads {
+			operations[i] = "download"
+		} else {
+			operations[i] = "upload"
+		}
+	}
+	mathRand.Shuffle(len(operations), func(i, j int) { operations[i], operations[j] = operations[j], operations[i] })
+	return operations
+}
+
+// Measures the deletion of each of the provided files, which should already be uploaded to the repository, and then
+// uploads all of them again to a single folder and measures the deletion of this folder.
+func MeasureDeleteOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	for _, file := range fileNames {
+		var result []BenchmarkResult
+		deleteError := MeasureSingleOperation(file, st, servicesManager, &result, DeleteFiles)
+		if deleteError != nil {
+			return deleteError
+		}
+		result[0].Operation = "delete"
+		*benchmarkResults = append(*benchmarkResults, result[0])
+	}
+
+	log.Info("Uploading the files again to [" + bulkDeleteFolder + "] folder for measuring bulk deletion")
+	for _, file := range fileNames {
+		_, uploadError := UploadFiles(file, st.RepositoryName+"/"+bulkDeleteFolder+"/", servicesManager)
+		if uploadError != nil {
+			return uploadError
+		}
+	}
+	duration, deleteError := DeleteFolder(bulkDeleteFolder, st.RepositoryName, servicesManager)
+	if deleteError != nil {
+		return deleteError
+	}
+	*benchmarkResults = append(*benchmarkResults, *NewMBBenchmarkResult("bulk-delete", st.RepositoryName+"/"+bulkDeleteFolder+"/",
+		st.FilesSizesInMb*len(fileNames), duration))
+	return nil
+}
+
+// The folder inside the repository the files are uploaded to for measuring their deletion in a single call.
+const bulkDeleteFolder = "bulk"
+
+// Measures each of the AQL queries, search patterns and properties searches of the config Repeats times. The queries and
+// patterns may contain the {repo} placeholder, and the properties are searched in all the files of the repository.
+func MeasureSearchOperationTimes(st *BenchmarkConfig, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	for _, query := range SplitSearchList(st.AqlQueries) {
+		query = strings.ReplaceAll(query, repoPlaceholder, st.RepositoryName)
+		for i := 0; i < st.Repeats; i++ {
+			duration, items, err := SearchAql(query, servicesManager)
+			if err != nil {
+				return err
+			}
+			*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("aql", query, items, duration))
+		}
+	}
+	for _, pattern := range SplitSearchList(st.SearchPatterns) {
+		pattern = strings.ReplaceAll(pattern, repoPlaceholder, st.RepositoryName)
+		for i := 0; i < st.Repeats; i++ {
+			duration, items, err := SearchPattern(pattern, "", servicesManager)
+			if err != nil {
+				return err
+			}
+			*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("pattern", pattern, items, duration))
+		}
+	}
+	for _, props := range SplitSearchList(st.SearchProps) {
+		for i := 0; i < st.Repeats; i++ {
+			duration, items, err := SearchPattern(st.RepositoryName+"/*", props, servicesManager)
+			if err != nil {
+				return err
+			}
+			*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult("props", props, items, duration))
+		}
+	}
+	return nil
+}
+
+// The placeholder in the search queries and patterns that is replaced with the repository name.
+const repoPlaceholder = "{repo}"
+
+// Splits the "|" separated list of queries, patterns or properties provided by the user.
+func SplitSearchList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, "|") {
+		if strings.TrimSpace(item) != "" {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+	return items
+}
+
+// Returns the properties set on the seeded file with the given index, the files are split to ten groups so searches by
+// benchmark.group return a tenth of the files.
+func GetSeedProps(index int) string {
+	return fmt.Sprintf("benchmark.index=%d;benchmark.group=%d", index, index%10)
+}
+
+// Sets PropsCount properties on each of the provided files, which should already be uploaded to the repository, then
+// reads and deletes them. The files are handled by Concurrency concurrent workers.
+func MeasurePropsOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	props, keys := GetBenchmarkProps(st.PropsCount)
+	return runConcurrently(st.Concurrency, len(fileNames), servicesManager, benchmarkResults, func(i int, servicesManager artifactory.ArtifactoryServicesManager) ([]BenchmarkResult, error) {
+		var results []BenchmarkResult
+		artifactPath := GetArtifactPath(st.RepositoryName, fileNames[i])
+		duration, err := SetProps(fileNames[i], st.RepositoryName, props, servicesManager)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, *NewItemsBenchmarkResult("set-props", artifactPath, st.PropsCount, duration))
+		duration, propsFound, err := GetProps(fileNames[i], st.RepositoryName, servicesManager)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, *NewItemsBenchmarkResult("get-props", artifactPath, propsFound, duration))
+		duration, err = DeleteProps(fileNames[i], st.RepositoryName, keys, servicesManager)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, *NewItemsBenchmarkResult("delete-props", artifactPath, st.PropsCount, duration))
+		return results, nil
+	})
+}
+
+// Returns the given number of properties in the "key1=value1;key2=value2" format, and their keys in the "key1,key2" format.
+func GetBenchmarkProps(count int) (string, string) {
+	var props, keys []string
+	for i := 1; i <= count; i++ {
+		props = append(props, fmt.Sprintf("benchmark.prop%d=value%d", i, i))
+		keys = append(keys, fmt.Sprintf("benchmark.prop%d", i))
+	}
+	return strings.Join(props, ";"), strings.Join(keys, ",")
+}
+
+// Copies, or moves if the config operation is "move", each of the provided files, which should already be uploaded to the
+// repository, to the destination repository. Then uploads all of them again to a single folder and measures copying or
+// moving this folder.
+func MeasureMoveCopyOperationTimes(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager,
+	benchmarkResults *[]BenchmarkResult) error {
+	move := st.Operation == MoveOperation
+	for _, file := range fileNames {
+		duration, err := MoveCopyFiles(file, st.RepositoryName, st.DestinationRepositoryName, move, servicesManager)
+		if err != nil {
+			return err
+		}
+		*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult(string(st.Operation), GetArtifactPath(st.RepositoryName, file), 1, duration))
+	}
+
+	log.Info("Uploading the files again to [" + moveCopyFolder + "] folder for measuring " + string(st.Operation) + " of a folder")
+	for _, file := range fileNames {
+		_, uploadError := UploadFiles(file, st.RepositoryName+"/"+moveCopyFolder+"/", servicesManager)
+		if uploadError != nil {
+			return uploadError
+		}
+	}
+	duration, err := MoveCopyFolder(moveCopyFolder, st.RepositoryName, st.DestinationRepositoryName, move, servicesManager)
+	if err != nil {
+		return err
+	}
+	*benchmarkResults = append(*benchmarkResults, *NewItemsBenchmarkResult(string(st.Operation)+"-folder", st.RepositoryName+"/"+moveCopyFolder+"/", len(fileNames), duration))
+	return nil
+}
+
+// The folder inside the repository the files are uploaded to for measuring copying or moving them in a single call.
+const moveCopyFolder = "folder"
+
 type runFunc func(fileName string, repositoryName string, servicesManager artifactory.ArtifactoryServicesManager) (time.Duration, error)
 
 func MeasureSingleOperation(file string, st *BenchmarkConfig, serviceManager artifactory.ArtifactoryServicesManager,
 	benchmarkResults *[]BenchmarkResult, operation runFunc) error {
 	duration, downloadError := operation(file, st.RepositoryName, serviceManager)
+	observeFileOperation(st, MeasurePhase, duration, downloadError)
 	if downloadError != nil {
 		return downloadError
 	}
-	sizeMbIntFormat, _ := strconv.Atoi(st.FilesSizesInMb)
-	uploadedMB := int64(sizeMbIntFormat)
-	speed := float64(uploadedMB) / duration.Seconds()
-	*benchmarkResults = append(*benchmarkResults, *NewBenchmarkResult(file, st.FilesSizesInMb, fmt.Sprintf("%s", duration), fmt.Sprintf("%.2f", speed)))
+	speed := float64(st.FilesSizesInMb) / duration.Seconds()
+	*benchmarkResults = append(*benchmarkResults, *NewBenchmarkResult(file, strconv.Itoa(st.FilesSizesInMb), fmt.Sprintf("%s", duration), fmt.Sprintf("%.2f", speed)))
 	return nil
 }
 
@@ -107,13 +369,177 @@ func CreateDirectory(path string, dirName string) string {
 	return newPath
 }
 
-func CheckIntLikeString(str string) error {
-	value, err := strconv.Atoi(str)
+// Returns an error naming the setting, by the name of its flag, if its value isn't positive.
+func CheckPositive(name string, value int) error {
+	if value <= 0 {
+		return errors.New(name + " must be positive")
+	}
+	return nil
+}
+
+// Returns the first of the errors that isn't nil, such as the first failed check of a number of settings.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validates the options of the up command, an archive can't contain the same file more than once, and is uploaded once.
+func ValidateUploadInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.Explode && cliConfig.SameFile {
+		return errors.New("The explode and same_file options can't be used together")
+	}
+	if cliConfig.Explode && cliConfig.Rate > 0 {
+		return errors.New("The explode and rate options can't be used together")
+	}
+	return nil
+}
+
+// Validates the options of the tree command, a tree of depth 0 is a single directory.
+func ValidateTreeInput(cliConfig *BenchmarkConfig) error {
+	serverAndAppendErr := validateServerAndAppendInput(cliConfig)
+	if serverAndAppendErr != nil {
+		return serverAndAppendErr
+	}
+	err := firstError(CheckPositive("files", cliConfig.TreeFiles), CheckPositive("fan_out", cliConfig.TreeFanOut),
+		CheckPositive("file_size", cliConfig.FileSizeInKb), CheckPositive("threads", cliConfig.Threads))
 	if err != nil {
-		return errors.New("Error: " + str + " is not an integer-like string.")
+		return err
 	}
-	if value <= 0 {
-		return errors.New("Iterations and size must be positive")
+	if cliConfig.TreeDepth < 0 {
+		return errors.New("Depth of the tree must be zero or positive")
+	}
+	return ValidateRepoNameInput(cliConfig.RepositoryName)
+}
+
+// Validates the split settings of the downloads.
+func ValidateSplitInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.SplitCount < 0 || cliConfig.MinSplitSize < 0 {
+		return errors.New("Split count and minimal split size must be zero or positive")
+	}
+	return nil
+}
+
+func ValidateRangeInput(cliConfig *BenchmarkConfig) error {
+	err := firstError(CheckPositive("range_size", cliConfig.RangeSize), CheckPositive("ranges", cliConfig.Repeats))
+	if err != nil {
+		return err
+	}
+	if cliConfig.RangePattern != SequentialRanges && cliConfig.RangePattern != RandomRanges {
+		return errors.New("Pattern of the ranges must be either " + SequentialRanges + " or " + RandomRanges)
+	}
+	return nil
+}
+
+// Validates the input of the docker command, which uses a Docker repository and doesn't generate files.
+func ValidateDockerInput(cliConfig *BenchmarkConfig) error {
+	serverAndAppendErr := validateServerAndAppendInput(cliConfig)
+	if serverAndAppendErr != nil {
+		return serverAndAppendErr
+	}
+	err := firstError(CheckPositive("images", cliConfig.Iterations), CheckPositive("layers", cliConfig.Layers),
+		CheckPositive("layer_size", cliConfig.FileSizeInKb))
+	if err != nil {
+		return err
+	}
+	if !dockerImageNameRegexp.MatchString(cliConfig.ImageName) {
+		return errors.New("Image name must contain only lowercase letters, digits and separators, such as benchmark/image")
+	}
+	return ValidateRepoNameInput(cliConfig.RepositoryName)
+}
+
+// The name components of Docker images, as defined by the Docker Registry v2 API.
+var dockerImageNameRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+
+// Validates the open-loop options, the rate is optional and may be fractional, e.g. 0.5 for an operation every 2 seconds.
+func ValidateRateInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.Rate == 0 {
+		return nil
+	}
+	if cliConfig.Rate < 0 {
+		return errors.New("Rate must be a positive number of operations per second")
+	}
+	return CheckPositive("concurrency", cliConfig.Concurrency)
+}
+
+// Validates the ramp options, the ramp is optional and replaces both the one by one operations and the fixed rate.
+func ValidateRampInput(cliConfig *BenchmarkConfig) error {
+	if len(cliConfig.Ramp) == 0 {
+		return nil
+	}
+	if cliConfig.Rate > 0 || cliConfig.Explode {
+		return errors.New("The ramp option can't be used together with the rate or explode options")
+	}
+	for _, level := range cliConfig.Ramp {
+		if level <= 0 {
+			return errors.New("The concurrency levels of the ramp must be positive integers")
+		}
+	}
+	if cliConfig.StepDuration < time.Second {
+		return errors.New("Step duration must be a positive number of seconds")
+	}
+	return nil
+}
+
+// Validates the bandwidth limit options, the limit is optional and applies to every stream or to all of them together.
+func ValidateBandwidthInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.BandwidthLimit == 0 {
+		return nil
+	}
+	if cliConfig.BandwidthScope != WorkerBandwidthScope && cliConfig.BandwidthScope != TotalBandwidthScope {
+		return errors.New("Bandwidth scope must be either '" + WorkerBandwidthScope + "' or '" + TotalBandwidthScope + "'")
+	}
+	if cliConfig.BandwidthLimit < 0 {
+		return errors.New("Bandwidth limit must be positive")
+	}
+	return nil
+}
+
+func ValidateMixedInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.ReadRatio < 0 || cliConfig.ReadRatio > 100 {
+		return errors.New("Read ratio must be a percentage between 0 and 100")
+	}
+	if cliConfig.Concurrency <= 0 {
+		return errors.New("Concurrency must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateSearchInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.Repeats <= 0 {
+		return errors.New("Repeats must be a positive integer")
+	}
+	if len(SplitSearchList(cliConfig.AqlQueries))+len(SplitSearchList(cliConfig.SearchPatterns))+len(SplitSearchList(cliConfig.SearchProps)) == 0 {
+		return errors.New("At least one AQL query, search pattern or properties search must be provided")
+	}
+	for _, props := range SplitSearchList(cliConfig.SearchProps) {
+		if !strings.Contains(props, "=") {
+			return errors.New("The properties search [" + props + "] must be in the key1=value1;key2=value2 format")
+		}
+	}
+	return nil
+}
+
+func ValidatePropsInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.PropsCount <= 0 {
+		return errors.New("Number of properties must be a positive integer")
+	}
+	if cliConfig.Concurrency <= 0 {
+		return errors.New("Concurrency must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateMoveCopyInput(cliConfig *BenchmarkConfig) error {
+	err := ValidateRepoNameInput(cliConfig.DestinationRepositoryName)
+	if err != nil {
+		return err
+	}
+	if cliConfig.DestinationRepositoryName == cliConfig.RepositoryName {
+		return errors.New("The destination repository must be different than the source repository")
 	}
 	return nil
 }
@@ -133,6 +559,41 @@ func UrlStartsWithHttpMethod(url string) bool {
 }
 
 func ValidateInput(cliConfig *BenchmarkConfig) error {
+	serverAndAppendErr := validateServerAndAppendInput(cliConfig)
+	if serverAndAppendErr != nil {
+		return serverAndAppendErr
+	}
+	positiveErr := firstError(CheckPositive("size", cliConfig.FilesSizesInMb), CheckPositive("iterations", cliConfig.Iterations))
+	if positiveErr != nil {
+		return positiveErr
+	}
+	RepoNameNotValidError := ValidateRepoNameInput(cliConfig.RepositoryName)
+	if RepoNameNotValidError != nil {
+		return RepoNameNotValidError
+	}
+	return nil
+}
+
+// Validates the input of the build-info command, which doesn't use a repository nor generate files.
+func ValidateBuildInput(cliConfig *BenchmarkConfig) error {
+	serverAndAppendErr := validateServerAndAppendInput(cliConfig)
+	if serverAndAppendErr != nil {
+		return serverAndAppendErr
+	}
+	err := firstError(CheckPositive("iterations", cliConfig.Iterations), CheckPositive("modules", cliConfig.Modules))
+	if err != nil {
+		return err
+	}
+	if cliConfig.ArtifactsPerModule < 0 || cliConfig.DependenciesPerModule < 0 {
+		return errors.New("Number of artifacts and dependencies per module must be zero or positive")
+	}
+	if cliConfig.BuildName == "" || strings.ContainsAny(cliConfig.BuildName, "/\\") {
+		return errors.New("Build name must not be empty, and must not contain slashes")
+	}
+	return nil
+}
+
+func validateServerAndAppendInput(cliConfig *BenchmarkConfig) error {
 	isCustomCredsProvided, customCredsErr := IsCustomCredsProvided(cliConfig)
 	if customCredsErr != nil {
 		return customCredsErr
@@ -143,14 +604,6 @@ func ValidateInput(cliConfig *BenchmarkConfig) error {
 			return err
 		}
 	}
-	StringsIntLikeErr := validateIntStringsLikeInput(cliConfig)
-	if StringsIntLikeErr != nil {
-		return StringsIntLikeErr
-	}
-	RepoNameNotValidError := ValidateRepoNameInput(cliConfig.RepositoryName)
-	if RepoNameNotValidError != nil {
-		return RepoNameNotValidError
-	}
 	if cliConfig.Append != "" {
 		_, err := FileExists(cliConfig.Append)
 		if err != nil {
@@ -160,18 +613,6 @@ func ValidateInput(cliConfig *BenchmarkConfig) error {
 	return nil
 }
 
-func validateIntStringsLikeInput(cliConfig *BenchmarkConfig) error {
-	filesSizeErr := CheckIntLikeString(cliConfig.FilesSizesInMb)
-	if filesSizeErr != nil {
-		return filesSizeErr
-	}
-	iterationsErr := CheckIntLikeString(cliConfig.Iterations)
-	if iterationsErr != nil {
-		return iterationsErr
-	}
-	return nil
-}
-
 func validateUrlInput(cliConfig *BenchmarkConfig) error {
 	CustomCredsProvided, customCredsErr := IsCustomCredsProvided(cliConfig)
 	if customCredsErr != nil {
@@ -208,24 +649,34 @@ func CleanupCliResources(config *BenchmarkConfig, servicesManager artifactory.Ar
 	if deleteRepoError != nil {
 		return deleteRepoError
 	}
+	if config.DestinationRepositoryName != "" {
+		deleteRepoError = DeleteRepository(config.DestinationRepositoryName, servicesManager)
+		if deleteRepoError != nil {
+			return deleteRepoError
+		}
+	}
 	deleteFilesError := DeleteLocalFilesAndTestDirectory(config.Iterations, config.SameFile)
 	if deleteFilesError != nil {
 		return deleteFilesError
 	}
+	deleteDownloadsError := os.RemoveAll(DownloadDirectory)
+	if deleteDownloadsError != nil {
+		return deleteDownloadsError
+	}
 	log.Info("Finished cleanup CLI resources")
 	return nil
 }
 
-func DeleteLocalFilesAndTestDirectory(Iterations string, sameFile bool) error {
+func DeleteLocalFilesAndTestDirectory(iterations int, sameFile bool) error {
 	log.Info("Deleting files generated for test")
-	IterationsInt, _ := strconv.Atoi(Iterations)
-	for i := 1; i < IterationsInt+1; i++ {
+	for i := 1; i < iterations+1; i++ {
 		if sameFile && i != 1 {
 			continue
 		}
 		fileName := fmt.Sprintf("/tmp/testfiles/File%v.txt", i)
+		// The files of a scenario phase that didn't run were never generated.
 		removingErr := os.Remove(fileName)
-		if removingErr != nil {
+		if removingErr != nil && !os.IsNotExist(removingErr) {
 			return removingErr
 		}
 	}
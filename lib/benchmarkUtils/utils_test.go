@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -33,25 +34,10 @@ func TestGenerateFiles(t *testing.T) {
 	}
 }
 
-var checkIntLikeStringProvider = []struct {
-	numString string
-}{
-	{"8"},
-	{"9"},
-	{"-1"},
-}
-
-func TestCheckIntLikeString(t *testing.T) {
-	for _, sample := range checkIntLikeStringProvider {
-		err := CheckIntLikeString(sample.numString)
-		if err != nil {
-			if strings.Contains(err.Error(), "must be positive") {
-				t.Logf("Test for positive number PASSED")
-			} else {
-				t.Error(err)
-			}
-		}
-	}
+func TestCheckPositive(t *testing.T) {
+	assert.NoError(t, CheckPositive("iterations", 8))
+	assert.EqualError(t, CheckPositive("size", -1), "size must be positive")
+	assert.EqualError(t, CheckPositive("fan_out", 0), "fan_out must be positive")
 }
 
 func TestUrlStartWithHttpMethod(t *testing.T) {
@@ -104,7 +90,7 @@ func TestDeleteLocalFiles(t *testing.T) {
 	}
 
 	// Call the function being tested
-	err = DeleteLocalFilesAndTestDirectory("3", false)
+	err = DeleteLocalFilesAndTestDirectory(3, false)
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
@@ -119,6 +105,14 @@ func TestDeleteLocalFiles(t *testing.T) {
 			t.Errorf("Expected test file %s to be deleted, but it still exists", fileName)
 		}
 	}
+
+	// The files which were never generated, such as the ones of the scenario phases which didn't run, are skipped.
+	assert.NoError(t, os.MkdirAll("/tmp/testfiles", os.ModePerm))
+	assert.NoError(t, ioutil.WriteFile("/tmp/testfiles/File1.txt", []byte("test"), os.ModePerm))
+	assert.NoError(t, DeleteLocalFilesAndTestDirectory(3, false))
+	if _, err := os.Stat("/tmp/testfiles"); !os.IsNotExist(err) {
+		t.Error("Expected test directory to be deleted, but it still exists")
+	}
 }
 func TestReadFileAndPrint(t *testing.T) {
 	// Define test variables
@@ -159,3 +153,304 @@ func TestReadFileAndPrint(t *testing.T) {
 		t.Errorf("Expected output '%s', but got '%s'", expectedOutput, outputBytes)
 	}
 }
+
+func TestGetMixedOperations(t *testing.T) {
+	operations := GetMixedOperations(10, 80)
+	counts := map[string]int{}
+	for _, operation := range operations {
+		counts[operation]++
+	}
+	assert.Equal(t, map[string]int{"download": 8, "upload": 2}, counts)
+	assert.Equal(t, []string{"upload", "upload", "upload"}, GetMixedOperations(3, 0))
+	assert.Equal(t, []string{"download", "download", "download"}, GetMixedOperations(3, 100))
+}
+
+func TestValidateMixedInput(t *testing.T) {
+	assert.NoError(t, ValidateMixedInput(&BenchmarkConfig{ReadRatio: 0, Concurrency: 1}))
+	assert.NoError(t, ValidateMixedInput(&BenchmarkConfig{ReadRatio: 100, Concurrency: 8}))
+	assert.Equal(t, errors.New("Read ratio must be a percentage between 0 and 100"), ValidateMixedInput(&BenchmarkConfig{ReadRatio: 101, Concurrency: 1}))
+	assert.Equal(t, errors.New("Concurrency must be a positive integer"), ValidateMixedInput(&BenchmarkConfig{ReadRatio: 50, Concurrency: 0}))
+}
+
+func TestMeasureMixedOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-mixed-tests/File1.txt": []byte("first artifact"),
+		"benchmark-mixed-tests/File2.txt": []byte("second artifact"),
+	})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-mixed-tests", FilesSizesInMb: 1, Iterations: 10, ReadRatio: 60, Concurrency: 3}
+	err := MeasureMixedOperationTimes(config, fileNames, servicesManager, &results)
+	assert.NoError(t, err)
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Operation]++
+	}
+	assert.Equal(t, map[string]int{"download": 6, "upload": 4}, counts)
+	assert.Len(t, fake.downloaded, 6)
+	assert.Len(t, fake.uploaded, 4)
+	for _, uploaded := range fake.uploaded {
+		assert.True(t, strings.HasPrefix(uploaded, "benchmark-mixed-tests/writes/"), "Unexpected upload path "+uploaded)
+	}
+
+	// Every upload has its own path, even when all the operations use the same file.
+	fake.uploaded = nil
+	err = MeasureMixedOperationTimes(config, []string{fileNames[0], fileNames[0]}, servicesManager, &results)
+	assert.NoError(t, err)
+	uploadedPaths := map[string]bool{}
+	for _, uploaded := range fake.uploaded {
+		uploadedPaths[uploaded] = true
+	}
+	assert.Len(t, uploadedPaths, 4)
+}
+
+func TestMeasureDeleteOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-del-tests/File1.txt": []byte("first artifact"),
+		"benchmark-del-tests/File2.txt": []byte("second artifact"),
+	})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-del-tests", FilesSizesInMb: 3}
+	err := MeasureDeleteOperationTimes(config, fileNames, servicesManager, &results)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "delete", results[0].Operation)
+		assert.Equal(t, "delete", results[1].Operation)
+		assert.Equal(t, "bulk-delete", results[2].Operation)
+		assert.Equal(t, "benchmark-del-tests/bulk/", results[2].FileName)
+		assert.Equal(t, "6", results[2].Size)
+	}
+	assert.ElementsMatch(t, []string{"benchmark-del-tests/File1.txt", "benchmark-del-tests/File2.txt",
+		"benchmark-del-tests/bulk/File1.txt", "benchmark-del-tests/bulk/File2.txt"}, fake.deleted)
+	assert.Empty(t, fake.artifacts)
+}
+
+func TestSplitSearchList(t *testing.T) {
+	assert.Equal(t, []string{"{repo}/*", "{repo}/File1*"}, SplitSearchList("{repo}/* | {repo}/File1*|"))
+	assert.Empty(t, SplitSearchList(""))
+}
+
+func TestGetSeedProps(t *testing.T) {
+	assert.Equal(t, "benchmark.index=1;benchmark.group=1", GetSeedProps(1))
+	assert.Equal(t, "benchmark.index=23;benchmark.group=3", GetSeedProps(23))
+}
+
+func TestValidateSearchInput(t *testing.T) {
+	assert.NoError(t, ValidateSearchInput(&BenchmarkConfig{Repeats: 1, SearchPatterns: "{repo}/*"}))
+	assert.Equal(t, errors.New("Repeats must be a positive integer"), ValidateSearchInput(&BenchmarkConfig{Repeats: 0, SearchPatterns: "{repo}/*"}))
+	assert.Equal(t, errors.New("At least one AQL query, search pattern or properties search must be provided"), ValidateSearchInput(&BenchmarkConfig{Repeats: 1}))
+	assert.Equal(t, errors.New("The properties search [benchmark.group] must be in the key1=value1;key2=value2 format"),
+		ValidateSearchInput(&BenchmarkConfig{Repeats: 1, SearchProps: "benchmark.group"}))
+}
+
+func TestMeasureSearchOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-search-tests/File1.txt": []byte("first artifact"),
+		"benchmark-search-tests/File2.txt": []byte("second artifact"),
+	})
+	defer fake.Close()
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-search-tests", Repeats: 2,
+		AqlQueries:     `items.find({"repo":"{repo}","path":".","name":{"$match":"*"}})`,
+		SearchPatterns: "{repo}/File1*|{repo}/*",
+		SearchProps:    "benchmark.group=1"}
+	err := MeasureSearchOperationTimes(config, servicesManager, &results)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 8) {
+		assert.Equal(t, BenchmarkResult{FileName: `items.find({"repo":"benchmark-search-tests","path":".","name":{"$match":"*"}})`,
+			Size: "2", Duration: results[0].Duration, Speed: results[0].Speed, Operation: "aql"}, results[0])
+		assert.Equal(t, "pattern", results[2].Operation)
+		assert.Equal(t, "benchmark-search-tests/File1*", results[2].FileName)
+		assert.Equal(t, "1", results[2].Size)
+		assert.Equal(t, "benchmark-search-tests/*", results[4].FileName)
+		assert.Equal(t, "2", results[4].Size)
+		assert.Equal(t, "props", results[6].Operation)
+		assert.Equal(t, "benchmark.group=1", results[6].FileName)
+	}
+}
+
+func TestGetBenchmarkProps(t *testing.T) {
+	props, keys := GetBenchmarkProps(3)
+	assert.Equal(t, "benchmark.prop1=value1;benchmark.prop2=value2;benchmark.prop3=value3", props)
+	assert.Equal(t, "benchmark.prop1,benchmark.prop2,benchmark.prop3", keys)
+}
+
+func TestValidatePropsInput(t *testing.T) {
+	assert.NoError(t, ValidatePropsInput(&BenchmarkConfig{PropsCount: 10, Concurrency: 4}))
+	assert.Equal(t, errors.New("Number of properties must be a positive integer"), ValidatePropsInput(&BenchmarkConfig{PropsCount: 0, Concurrency: 4}))
+	assert.Equal(t, errors.New("Concurrency must be a positive integer"), ValidatePropsInput(&BenchmarkConfig{PropsCount: 10, Concurrency: 0}))
+}
+
+func TestMeasurePropsOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-props-tests/File1.txt": []byte("first artifact"),
+		"benchmark-props-tests/File2.txt": []byte("second artifact"),
+		"benchmark-props-tests/File3.txt": []byte("third artifact"),
+	})
+	defer fake.Close()
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-props-tests", PropsCount: 5, Concurrency: 2}
+	fileNames := []string{"/tmp/testfiles/File1.txt", "/tmp/testfiles/File2.txt", "/tmp/testfiles/File3.txt"}
+	err := MeasurePropsOperationTimes(config, fileNames, servicesManager, &results)
+	assert.NoError(t, err)
+	assert.Len(t, results, 9)
+	for _, result := range results {
+		assert.Contains(t, []string{"set-props", "get-props", "delete-props"}, result.Operation)
+		assert.Equal(t, "5", result.Size)
+	}
+	for _, props := range fake.props {
+		assert.Empty(t, props)
+	}
+}
+
+func TestValidateMoveCopyInput(t *testing.T) {
+	assert.NoError(t, ValidateMoveCopyInput(&BenchmarkConfig{RepositoryName: "source", DestinationRepositoryName: "destination"}))
+	assert.Equal(t, errors.New("The destination repository must be different than the source repository"),
+		ValidateMoveCopyInput(&BenchmarkConfig{RepositoryName: "source", DestinationRepositoryName: "source"}))
+	assert.Error(t, ValidateMoveCopyInput(&BenchmarkConfig{RepositoryName: "source", DestinationRepositoryName: ""}))
+}
+
+func TestMeasureMoveCopyOperationTimes(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-move-tests/File1.txt": []byte("first artifact"),
+		"benchmark-move-tests/File2.txt": []byte("second artifact"),
+	})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+
+	var results []BenchmarkResult
+	config := &BenchmarkConfig{RepositoryName: "benchmark-move-tests", DestinationRepositoryName: "benchmark-move-dest-tests", Operation: "move"}
+	err := MeasureMoveCopyOperationTimes(config, fileNames, servicesManager, &results)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "move", results[0].Operation)
+		assert.Equal(t, "1", results[0].Size)
+		assert.Equal(t, "move-folder", results[2].Operation)
+		assert.Equal(t, "benchmark-move-tests/folder/", results[2].FileName)
+		assert.Equal(t, "2", results[2].Size)
+	}
+	var paths []string
+	for artifactPath := range fake.artifacts {
+		paths = append(paths, artifactPath)
+	}
+	assert.ElementsMatch(t, []string{"benchmark-move-dest-tests/File1.txt", "benchmark-move-dest-tests/File2.txt",
+		"benchmark-move-dest-tests/folder/File1.txt", "benchmark-move-dest-tests/folder/File2.txt"}, paths)
+}
+
+// Creates small files in a new temporary directory, the caller is responsible for removing the returned directory.
+func createLocalTestFiles(t *testing.T, numberOfFiles int) (string, []string) {
+	localDir, err := ioutil.TempDir("", "benchmark")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	var fileNames []string
+	for i := 1; i <= numberOfFiles; i++ {
+		fileName := fmt.Sprintf("%s/File%v.txt", localDir, i)
+		err := ioutil.WriteFile(fileName, []byte("local file"), os.ModePerm)
+		if err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	return localDir, fileNames
+}
+
+func TestValidateBuildInput(t *testing.T) {
+	valid := BenchmarkConfig{Iterations: 10, Modules: 10, ArtifactsPerModule: 0, DependenciesPerModule: 100, BuildName: "benchmark-build-tests"}
+	config := valid
+	assert.NoError(t, ValidateBuildInput(&config))
+	config = valid
+	config.Modules = 0
+	assert.Error(t, ValidateBuildInput(&config))
+	config = valid
+	config.DependenciesPerModule = -1
+	assert.Error(t, ValidateBuildInput(&config))
+	config = valid
+	config.BuildName = "benchmark/build"
+	assert.Error(t, ValidateBuildInput(&config))
+}
+
+func TestValidateUploadInput(t *testing.T) {
+	assert.NoError(t, ValidateUploadInput(&BenchmarkConfig{Explode: true}))
+	assert.NoError(t, ValidateUploadInput(&BenchmarkConfig{SameFile: true}))
+	assert.Error(t, ValidateUploadInput(&BenchmarkConfig{Explode: true, SameFile: true}))
+}
+
+func TestValidateTreeInput(t *testing.T) {
+	valid := BenchmarkConfig{TreeFiles: 1000, TreeDepth: 0, TreeFanOut: 3, FileSizeInKb: 1, Threads: 3, RepositoryName: "benchmark-tree-tests"}
+	config := valid
+	assert.NoError(t, ValidateTreeInput(&config))
+	config = valid
+	config.TreeDepth = -1
+	assert.Error(t, ValidateTreeInput(&config))
+	config = valid
+	config.TreeFanOut = 0
+	assert.Error(t, ValidateTreeInput(&config))
+	config = valid
+	config.Threads = 0
+	assert.Error(t, ValidateTreeInput(&config))
+}
+
+func TestValidateSplitInput(t *testing.T) {
+	assert.NoError(t, ValidateSplitInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidateSplitInput(&BenchmarkConfig{SplitCount: 0, MinSplitSize: 5120}))
+	assert.Error(t, ValidateSplitInput(&BenchmarkConfig{SplitCount: -1}))
+	assert.Error(t, ValidateSplitInput(&BenchmarkConfig{MinSplitSize: -1}))
+}
+
+func TestValidateRangeInput(t *testing.T) {
+	assert.NoError(t, ValidateRangeInput(&BenchmarkConfig{RangeSize: 1024, Repeats: 10, RangePattern: SequentialRanges}))
+	assert.NoError(t, ValidateRangeInput(&BenchmarkConfig{RangeSize: 1, Repeats: 1, RangePattern: RandomRanges}))
+	assert.Error(t, ValidateRangeInput(&BenchmarkConfig{RangeSize: 0, Repeats: 10, RangePattern: SequentialRanges}))
+	assert.Error(t, ValidateRangeInput(&BenchmarkConfig{RangeSize: 1024, Repeats: 10, RangePattern: "backwards"}))
+}
+
+func TestValidateDockerInput(t *testing.T) {
+	valid := BenchmarkConfig{Iterations: 5, Layers: 3, FileSizeInKb: 10240, ImageName: "benchmark/image-1", RepositoryName: "benchmark-docker-tests"}
+	config := valid
+	assert.NoError(t, ValidateDockerInput(&config))
+	config = valid
+	config.Layers = 0
+	assert.Error(t, ValidateDockerInput(&config))
+	config = valid
+	config.ImageName = "Benchmark"
+	assert.Error(t, ValidateDockerInput(&config))
+	config = valid
+	config.ImageName = "benchmark/"
+	assert.Error(t, ValidateDockerInput(&config))
+}
+
+func TestValidateRateInput(t *testing.T) {
+	assert.NoError(t, ValidateRateInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidateRateInput(&BenchmarkConfig{Rate: 0.5, Concurrency: 10}))
+	assert.Error(t, ValidateRateInput(&BenchmarkConfig{Rate: -1, Concurrency: 10}))
+	assert.Error(t, ValidateRateInput(&BenchmarkConfig{Rate: 5, Concurrency: 0}))
+	assert.Error(t, ValidateUploadInput(&BenchmarkConfig{Explode: true, Rate: 5}))
+}
+
+func TestValidateRampInput(t *testing.T) {
+	assert.NoError(t, ValidateRampInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidateRampInput(&BenchmarkConfig{Ramp: []int{1, 2, 4}, StepDuration: 30 * time.Second}))
+	assert.Error(t, ValidateRampInput(&BenchmarkConfig{Ramp: []int{1, 2, 4}, StepDuration: 0}))
+	assert.Error(t, ValidateRampInput(&BenchmarkConfig{Ramp: []int{1, -2}, StepDuration: 30 * time.Second}))
+	assert.Error(t, ValidateRampInput(&BenchmarkConfig{Ramp: []int{1, 2, 4}, StepDuration: 30 * time.Second, Rate: 5}))
+}
+
+func TestValidateBandwidthInput(t *testing.T) {
+	assert.NoError(t, ValidateBandwidthInput(&BenchmarkConfig{}))
+	assert.NoError(t, ValidateBandwidthInput(&BenchmarkConfig{BandwidthLimit: 10 * 1024 * 1024, BandwidthScope: WorkerBandwidthScope}))
+	assert.NoError(t, ValidateBandwidthInput(&BenchmarkConfig{BandwidthLimit: 512 * 1024, BandwidthScope: TotalBandwidthScope}))
+	assert.Error(t, ValidateBandwidthInput(&BenchmarkConfig{BandwidthLimit: 10 * 1024 * 1024, BandwidthScope: "thread"}))
+	assert.Error(t, ValidateBandwidthInput(&BenchmarkConfig{BandwidthLimit: -1, BandwidthScope: WorkerBandwidthScope}))
+}
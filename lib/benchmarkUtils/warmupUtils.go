@@ -0,0 +1,43 @@
+package benchmarkUtils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The folder inside the repository the warm-up uploads are written to, so the measured uploads don't override them.
+const warmupUploadsFolder = "warmup"
+
+// Executes the Warmup number of operations of the config on the files, one after the other and starting over once all
+// the files are used, without recording them. They absorb the connection setup, the token refresh and the server caches,
+// which would otherwise skew the first measured operations.
+func RunWarmupOperations(st *BenchmarkConfig, fileNames []string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	if st.Warmup == 0 {
+		return nil
+	}
+	target := st.RepositoryName + "/" + warmupUploadsFolder + "/"
+	operation := UploadFilesWithoutChecksumDeploy
+	if st.Operation == DownloadOperation {
+		target = st.RepositoryName
+		operation = GetDownloadFunc(st)
+	}
+	log.Info(fmt.Sprintf("Running %d warm-up operations, they are excluded from the results", st.Warmup))
+	for i := 0; i < st.Warmup; i++ {
+		duration, err := operation(fileNames[i%len(fileNames)], target, servicesManager)
+		observeFileOperation(st, WarmupPhase, duration, err)
+		if err != nil {
+			return errors.New("Warm-up operation failed - " + err.Error())
+		}
+	}
+	return nil
+}
+
+func ValidateWarmupInput(cliConfig *BenchmarkConfig) error {
+	if cliConfig.Warmup < 0 {
+		return errors.New("Warmup must be a non-negative number of operations")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package benchmarkUtils
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWarmupOperations(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{
+		"benchmark-dl-tests/File1.txt": []byte("1"),
+		"benchmark-dl-tests/File2.txt": []byte("2"),
+	})
+	defer fake.Close()
+	defer os.RemoveAll(DownloadDirectory)
+	config := &BenchmarkConfig{Operation: "download", RepositoryName: "benchmark-dl-tests", Warmup: 3}
+	fileNames := []string{"/tmp/testfiles/File1.txt", "/tmp/testfiles/File2.txt"}
+
+	assert.NoError(t, RunWarmupOperations(config, fileNames, servicesManager))
+	// The warm-up starts over with the first file once all the files are used.
+	assert.Equal(t, []string{"benchmark-dl-tests/File1.txt", "benchmark-dl-tests/File2.txt", "benchmark-dl-tests/File1.txt"}, fake.downloaded)
+
+	config.Warmup = 0
+	assert.NoError(t, RunWarmupOperations(config, fileNames, servicesManager))
+	assert.Len(t, fake.downloaded, 3)
+
+	config.Warmup = 1
+	err := RunWarmupOperations(config, []string{"/tmp/testfiles/File3.txt"}, servicesManager)
+	assert.EqualError(t, err, "Warm-up operation failed - Failed to download files from Artifactory")
+}
+
+func TestRunUploadWarmupOperations(t *testing.T) {
+	fake, servicesManager := newFakeArtifactory(t, map[string][]byte{})
+	defer fake.Close()
+	localDir, fileNames := createLocalTestFiles(t, 2)
+	defer os.RemoveAll(localDir)
+	// Large enough to be deployed by its checksum by the measured upload, if the checksum deploy wasn't disabled.
+	assert.NoError(t, ioutil.WriteFile(fileNames[0], make([]byte, 20*1024), os.ModePerm))
+	config := &BenchmarkConfig{Operation: UploadOperation, RepositoryName: "benchmark-up-tests", FilesSizesInMb: 1, Warmup: 1}
+
+	// The warm-up uploads are written to their own folder, and the measured uploads send the content of the files again.
+	assert.NoError(t, RunWarmupOperations(config, fileNames, servicesManager))
+	var results []BenchmarkResult
+	assert.NoError(t, MeasureOperationTimes(config, fileNames, servicesManager, &results))
+	assert.Equal(t, []string{"benchmark-up-tests/warmup/File1.txt", "benchmark-up-tests/File1.txt", "benchmark-up-tests/File2.txt"}, fake.uploaded)
+	assert.Empty(t, fake.checksumDeploys)
+}
+
+func TestValidateWarmupInput(t *testing.T) {
+	for _, valid := range []int{0, 10} {
+		assert.NoError(t, ValidateWarmupInput(&BenchmarkConfig{Warmup: valid}), valid)
+	}
+	assert.EqualError(t, ValidateWarmupInput(&BenchmarkConfig{Warmup: -1}), "Warmup must be a non-negative number of operations")
+}
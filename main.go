@@ -2,6 +2,7 @@ package main
 
 import (
 	"benchmark/commands"
+	"benchmark/lib/benchmarkUtils"
 
 	"github.com/jfrog/jfrog-cli-core/v2/plugins"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
@@ -15,7 +16,7 @@ func getApp() components.App {
 	app := components.App{}
 	app.Name = "benchmark"
 	app.Description = "Easily test uploads/downloads"
-	app.Version = "v0.1.5"
+	app.Version = benchmarkUtils.PluginVersion
 	app.Commands = getCommands()
 	return app
 }
@@ -24,6 +25,17 @@ func getCommands() []components.Command {
 	return []components.Command{
 		commands.DownloadCommand(),
 		commands.UploadCommand(),
+		commands.MixedCommand(),
+		commands.DeleteCommand(),
+		commands.SearchCommand(),
+		commands.PropsCommand(),
+		commands.CopyCommand(),
+		commands.MoveCommand(),
+		commands.BuildCommand(),
+		commands.TreeCommand(),
+		commands.RangeCommand(),
+		commands.DockerCommand(),
+		commands.RunCommand(),
 	}
 
 }